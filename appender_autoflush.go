@@ -0,0 +1,232 @@
+package duckdbcapi
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+)
+
+var ErrAppenderClosed = errors.New("ErrAppenderClosed")
+
+// AutoFlushOptions sets when an AutoFlushAppender flushes. Zero values
+// disable a threshold.
+type AutoFlushOptions struct {
+	// MaxRows flushes once this many rows were appended since the last flush.
+	MaxRows uint64
+	// MaxBytes flushes once the estimated size of the appended values
+	// reaches this many bytes.
+	MaxBytes uint64
+	// Interval flushes pending rows periodically on a background goroutine.
+	Interval time.Duration
+	// OnError receives errors of background flushes. It is called without
+	// holding the AutoFlushAppender's lock, so it may call its methods,
+	// including Close.
+	// Without it they are returned by the next call on the AutoFlushAppender.
+	OnError func(error)
+}
+
+// AutoFlushAppender wraps an Appender and flushes it after MaxRows rows,
+// MaxBytes bytes or every Interval. It is safe for concurrent use; the
+// wrapped Appender must not be used directly while it is open.
+type AutoFlushAppender struct {
+	mu     sync.Mutex
+	a      *Appender
+	opts   AutoFlushOptions
+	rows   uint64
+	bytes  uint64
+	err    error
+	closed bool
+	stop   chan struct{}
+}
+
+func NewAutoFlushAppender(a *Appender, opts AutoFlushOptions) *AutoFlushAppender {
+	f := &AutoFlushAppender{a: a, opts: opts}
+	if opts.Interval > 0 {
+		f.stop = make(chan struct{})
+		go f.run()
+	}
+	return f
+}
+
+func (f *AutoFlushAppender) run() {
+	ticker := time.NewTicker(f.opts.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-f.stop:
+			return
+		case <-ticker.C:
+			var err error
+			f.mu.Lock()
+			if f.rows > 0 && !f.closed {
+				err = f.flush()
+			}
+			if err != nil && f.opts.OnError == nil && f.err == nil {
+				f.err = err
+			}
+			f.mu.Unlock()
+			// outside the lock, so the callback may use the appender
+			if err != nil && f.opts.OnError != nil {
+				f.opts.OnError(err)
+			}
+		}
+	}
+}
+
+// AppendRow appends a row with Appender.AppendRow.
+func (f *AutoFlushAppender) AppendRow(values ...any) error {
+	return f.append(func() error { return f.a.AppendRow(values...) }, 1, estimateSize(reflect.ValueOf(values)))
+}
+
+// AppendColumns appends columns with Appender.AppendColumns.
+func (f *AutoFlushAppender) AppendColumns(cols ...any) error {
+	var rows uint64
+	if len(cols) > 0 {
+		first := cols[0]
+		if nc, ok := first.(NullableColumn); ok {
+			first = nc.Values
+		}
+		if v := reflect.ValueOf(first); v.Kind() == reflect.Slice {
+			rows = uint64(v.Len())
+		}
+	}
+	return f.append(func() error { return f.a.AppendColumns(cols...) }, rows, estimateSize(reflect.ValueOf(cols)))
+}
+
+func (f *AutoFlushAppender) append(do func() error, rows, bytes uint64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.pending(); err != nil {
+		return err
+	}
+	if err := do(); err != nil {
+		return err
+	}
+	f.rows += rows
+	f.bytes += bytes
+	if (f.opts.MaxRows > 0 && f.rows >= f.opts.MaxRows) || (f.opts.MaxBytes > 0 && f.bytes >= f.opts.MaxBytes) {
+		return f.flush()
+	}
+	return nil
+}
+
+// pending returns ErrAppenderClosed or a background flush error that was
+// not reported yet.
+func (f *AutoFlushAppender) pending() error {
+	if f.closed {
+		return ErrAppenderClosed
+	}
+	err := f.err
+	f.err = nil
+	return err
+}
+
+func (f *AutoFlushAppender) flush() error {
+	f.rows, f.bytes = 0, 0
+	return f.a.Flush()
+}
+
+// Flush flushes the appended rows now.
+func (f *AutoFlushAppender) Flush() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.pending(); err != nil {
+		return err
+	}
+	return f.flush()
+}
+
+// Close stops the background flushes, flushes the remaining rows and closes
+// the Appender. The Appender still has to be destroyed.
+func (f *AutoFlushAppender) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return ErrAppenderClosed
+	}
+	f.closed = true
+	// the background goroutine does not flush once closed, so it is not
+	// waited for; OnError may be the one calling Close
+	if f.stop != nil {
+		close(f.stop)
+	}
+	err := f.err
+	f.err = nil
+	if flushErr := f.flush(); err == nil {
+		err = flushErr
+	}
+	if closeErr := f.a.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// estimateSize approximates the memory DuckDB needs for v: 16 bytes per
+// value, the string_t size, plus the length of strings and blobs.
+func estimateSize(v reflect.Value) uint64 {
+	return estimateSizeOnPath(v, nil)
+}
+
+// sizeVisit is a pointer, map or slice estimateSizeOnPath is inside of.
+type sizeVisit struct {
+	p   uintptr
+	typ reflect.Type
+}
+
+// estimateSizeOnPath counts a value that refers back to one of its
+// containers on path as a single value, so cyclic values end the recursion
+// and are left for AppendRow to reject.
+func estimateSizeOnPath(v reflect.Value, path []sizeVisit) uint64 {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return 16
+		}
+		visit := sizeVisit{v.Pointer(), v.Type()}
+		for _, seen := range path {
+			if seen == visit {
+				return 16
+			}
+		}
+		path = append(path, visit)
+	}
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if v.IsNil() {
+			return 16
+		}
+		return estimateSizeOnPath(v.Elem(), path)
+	case reflect.String:
+		return 16 + uint64(v.Len())
+	case reflect.Slice, reflect.Array:
+		switch elem := v.Type().Elem().Kind(); {
+		case elem == reflect.Uint8:
+			return 16 + uint64(v.Len())
+		case elem >= reflect.Bool && elem <= reflect.Complex128:
+			return 16 * uint64(v.Len())
+		}
+		var size uint64
+		for i := 0; i < v.Len(); i++ {
+			size += estimateSizeOnPath(v.Index(i), path)
+		}
+		return size
+	case reflect.Map:
+		var size uint64
+		iter := v.MapRange()
+		for iter.Next() {
+			size += estimateSizeOnPath(iter.Key(), path) + estimateSizeOnPath(iter.Value(), path)
+		}
+		return size
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return 16
+		}
+		var size uint64
+		for i := 0; i < v.NumField(); i++ {
+			size += estimateSizeOnPath(v.Field(i), path)
+		}
+		return size
+	}
+	return 16
+}
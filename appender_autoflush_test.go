@@ -0,0 +1,122 @@
+package duckdbcapi
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func countRows(t *testing.T, conn *Connection, table string) int64 {
+	var result Result
+	defer result.Destroy()
+	assert.Nil(t, conn.Query("SELECT count(*) FROM "+table, &result))
+	return result.ValueInt64(0, 0)
+}
+
+func TestAutoFlushAppenderInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+	assert.Nil(t, tester.NoResultQuery("CREATE TABLE logs (id INTEGER, msg VARCHAR)"))
+	reader, err := tester.db.Connection()
+	assert.Nil(t, err)
+	defer reader.Disconnect()
+
+	t.Run("rows and bytes", func(t *testing.T) {
+		appender, err := tester.conn.AppenderCreate("", "logs")
+		assert.Nil(t, err)
+		defer appender.Destroy()
+		f := NewAutoFlushAppender(appender, AutoFlushOptions{MaxRows: 3, MaxBytes: 1 << 10})
+
+		assert.Nil(t, f.AppendRow(1, "a"))
+		assert.Nil(t, f.AppendRow(2, "b"))
+		assert.Equal(t, int64(0), countRows(t, reader, "logs"))
+		assert.Nil(t, f.AppendRow(3, "c"))
+		assert.Equal(t, int64(3), countRows(t, reader, "logs"))
+
+		assert.Nil(t, f.AppendRow(4, string(make([]byte, 2<<10))))
+		assert.Equal(t, int64(4), countRows(t, reader, "logs"))
+
+		assert.Nil(t, f.AppendColumns([]int32{5, 6}, []string{"e", "f"}))
+		assert.Nil(t, f.Close())
+		assert.Equal(t, int64(6), countRows(t, reader, "logs"))
+		assert.ErrorIs(t, f.AppendRow(7, "g"), ErrAppenderClosed)
+		assert.ErrorIs(t, f.Close(), ErrAppenderClosed)
+	})
+
+	t.Run("cyclic value", func(t *testing.T) {
+		appender, err := tester.conn.AppenderCreate("", "logs")
+		assert.Nil(t, err)
+		defer appender.Destroy()
+		f := NewAutoFlushAppender(appender, AutoFlushOptions{MaxBytes: 1 << 10})
+		defer f.Close()
+
+		// the size estimate must not follow the cycle; AppendRow rejects it
+		cyclic := []any{nil}
+		cyclic[0] = cyclic
+		assert.ErrorIs(t, f.AppendRow(8, cyclic), ErrVectorTypeMismatch)
+		type node struct{ Next *node }
+		n := &node{}
+		n.Next = n
+		assert.ErrorIs(t, f.AppendRow(9, n), ErrVectorTypeMismatch)
+	})
+
+	t.Run("interval", func(t *testing.T) {
+		appender, err := tester.conn.AppenderCreate("", "logs")
+		assert.Nil(t, err)
+		defer appender.Destroy()
+		var (
+			mu   sync.Mutex
+			errs []error
+		)
+		f := NewAutoFlushAppender(appender, AutoFlushOptions{Interval: 10 * time.Millisecond, OnError: func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err)
+		}})
+
+		var wg sync.WaitGroup
+		for g := 0; g < 4; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < 50; i++ {
+					assert.Nil(t, f.AppendRow(100+g*50+i, "concurrent"))
+				}
+			}(g)
+		}
+		wg.Wait()
+		assert.Eventually(t, func() bool {
+			return countRows(t, reader, "logs WHERE msg = 'concurrent'") == 200
+		}, time.Second, 10*time.Millisecond)
+		assert.Nil(t, f.Close())
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Empty(t, errs)
+	})
+
+	t.Run("callback closes", func(t *testing.T) {
+		assert.Nil(t, tester.NoResultQuery("CREATE TABLE keys (id INTEGER PRIMARY KEY)"))
+		appender, err := tester.conn.AppenderCreate("", "keys")
+		assert.Nil(t, err)
+		defer appender.Destroy()
+		errs := make(chan error, 1)
+		var f *AutoFlushAppender
+		f = NewAutoFlushAppender(appender, AutoFlushOptions{Interval: 10 * time.Millisecond, OnError: func(err error) {
+			f.Close()
+			errs <- err
+		}})
+
+		assert.Nil(t, f.AppendRow(1))
+		assert.Nil(t, f.AppendRow(1))
+		select {
+		case err := <-errs:
+			assert.ErrorIs(t, err, ErrDuckDBError)
+		case <-time.After(time.Second):
+			t.Fatal("no flush error")
+		}
+		assert.ErrorIs(t, f.AppendRow(2), ErrAppenderClosed)
+	})
+}
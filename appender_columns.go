@@ -0,0 +1,194 @@
+package duckdbcapi
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+	"unsafe"
+)
+
+// NullableColumn is a column for AppendColumns with a validity mask: row i
+// is NULL when Valid[i] is false. A nil Valid means no NULLs.
+type NullableColumn struct {
+	Values any
+	Valid  []bool
+}
+
+// AppendColumns appends one Go slice per table column, all of the same
+// length. Slices are copied into DataChunks of VectorSize rows that are
+// handed to DuckDB with AppendDataChunk, so the cost in cgo calls is per
+// chunk rather than per cell, except for strings and blobs.
+//
+// Slices of the column's own Go type ([]int32 for INTEGER, []float64 for
+// DOUBLE, ...) are copied as is; []int64, []float64, []string, [][]byte and
+// []time.Time are converted like the VectorWriter setters where it accepts
+// the column type. Any other slice is written element by element with
+// AppendRow's conversions, including slices of pointers where nil is NULL.
+// Wrap a slice in NullableColumn to pass a validity mask.
+//
+// Chunks are converted and appended one at a time, so when a value cannot
+// be converted the chunks before it stay appended. The error names the
+// column and, where the value is at fault, its row counted from the first
+// row of the appender.
+func (a *Appender) AppendColumns(cols ...any) error {
+	if err := a.loadColumns(); err != nil {
+		return err
+	}
+	if len(cols) != len(a.types) {
+		return fmt.Errorf("%w: table has %d columns, got %d slices", ErrAppenderColumnMismatch, len(a.types), len(cols))
+	}
+	values := make([]reflect.Value, len(cols))
+	valid := make([][]bool, len(cols))
+	rows := -1
+	for i, col := range cols {
+		if nc, ok := col.(NullableColumn); ok {
+			col, valid[i] = nc.Values, nc.Valid
+		}
+		values[i] = reflect.ValueOf(col)
+		if values[i].Kind() != reflect.Slice {
+			return fmt.Errorf("%w: column %s: %T is not a slice", ErrAppenderColumnMismatch, a.names[i], col)
+		}
+		n := values[i].Len()
+		if valid[i] != nil && len(valid[i]) != n {
+			return fmt.Errorf("%w: column %s has %d values and %d validity entries", ErrAppenderColumnMismatch, a.names[i], n, len(valid[i]))
+		}
+		if rows >= 0 && n != rows {
+			return fmt.Errorf("%w: column %s has %d rows, expected %d", ErrAppenderColumnMismatch, a.names[i], n, rows)
+		}
+		rows = n
+	}
+	if err := a.flushStaged(); err != nil {
+		return err
+	}
+	// the columns are streamed through the appender's chunk, which must
+	// not keep cellWriters for the rows it held
+	destroyCellWriters(a.writers)
+	a.writers = nil
+	if a.chunk == nil {
+		chunk, err := CreateDataChunk(a.types, uint64(len(a.types)))
+		if err != nil {
+			return err
+		}
+		a.chunk = chunk
+	}
+	size := int(VectorSize())
+	for offset := 0; offset < rows; offset += size {
+		n := rows - offset
+		if n > size {
+			n = size
+		}
+		for i := range values {
+			vec, err := a.chunk.GetVector(uint64(i))
+			if err != nil {
+				a.chunk.Reset()
+				return err
+			}
+			var mask []bool
+			if valid[i] != nil {
+				mask = valid[i][offset : offset+n]
+			}
+			if err := fillColumn(vec, values[i].Slice(offset, offset+n), mask, a.rows); err != nil {
+				a.chunk.Reset()
+				return fmt.Errorf("column %s: %w", a.names[i], err)
+			}
+		}
+		a.chunk.SetSize(uint64(n))
+		err := a.appendChunk(a.chunk)
+		a.chunk.Reset()
+		if err != nil {
+			return err
+		}
+		a.rows += uint64(n)
+	}
+	return nil
+}
+
+// fillColumn writes values, a slice of at most VectorSize elements, to vec
+// from row 0. first is the number of the first row in error messages.
+func fillColumn(vec *Vector, values reflect.Value, valid []bool, first uint64) error {
+	w := NewVectorWriter(vec)
+	var err error
+	switch col := values.Interface().(type) {
+	case []bool:
+		err = copyColumn(w, col, DuckDBTypeBoolean)
+	case []int8:
+		err = copyColumn(w, col, DuckDBTypeTinyInt)
+	case []int16:
+		err = copyColumn(w, col, DuckDBTypeSmallInt)
+	case []int32:
+		err = copyColumn(w, col, DuckDBTypeInteger)
+	case []int64:
+		err = w.SetInt64s(col)
+	case []uint8:
+		err = copyColumn(w, col, DuckDBTypeUTinyInt)
+	case []uint16:
+		err = copyColumn(w, col, DuckDBTypeUSmallInt)
+	case []uint32:
+		err = copyColumn(w, col, DuckDBTypeUInteger)
+	case []uint64:
+		err = copyColumn(w, col, DuckDBTypeUBigInt)
+	case []float32:
+		err = copyColumn(w, col, DuckDBTypeFloat)
+	case []float64:
+		err = w.SetFloat64s(col)
+	case []string:
+		err = w.SetStrings(col)
+	case [][]byte:
+		err = w.SetBlobs(col)
+	case []time.Time:
+		err = w.SetTimes(col)
+	default:
+		return fillCells(vec, values, valid, first)
+	}
+	if errors.Is(err, ErrVectorTypeMismatch) || errors.Is(err, ErrVectorOutOfRange) {
+		// not a fast path for this column type, or a value that does not
+		// fit it: the VectorWriter numbers rows within the chunk, fillCells
+		// reports the row by first
+		return fillCells(vec, values, valid, first)
+	}
+	if err != nil || valid == nil {
+		return err
+	}
+	vec.EnsureValidityWritable()
+	validity, err := vec.GetValidity()
+	if err != nil {
+		return err
+	}
+	for row, ok := range valid {
+		if !ok {
+			validity.setRowInvalid(uint64(row))
+		}
+	}
+	return nil
+}
+
+// copyColumn copies values into a vector whose physical type is T.
+func copyColumn[T any](w *VectorWriter, values []T, typ Type) error {
+	var zero T
+	p, err := w.data(fmt.Sprintf("%T", zero), len(values), typ)
+	if err != nil {
+		return err
+	}
+	copy(unsafe.Slice((*T)(p), len(values)), values)
+	return nil
+}
+
+// fillCells writes values element by element with a cellWriter.
+func fillCells(vec *Vector, values reflect.Value, valid []bool, first uint64) error {
+	w, err := newCellWriter(vec)
+	if err != nil {
+		return err
+	}
+	defer w.destroy()
+	for row := 0; row < values.Len(); row++ {
+		var v any
+		if valid == nil || valid[row] {
+			v = values.Index(row).Interface()
+		}
+		if err := w.set(uint64(row), v); err != nil {
+			return fmt.Errorf("row %d: %w", first+uint64(row), err)
+		}
+	}
+	return nil
+}
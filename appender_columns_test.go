@@ -0,0 +1,168 @@
+package duckdbcapi
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendColumnsInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+	assert.Nil(t, tester.NoResultQuery(`CREATE TABLE metrics (id INTEGER, host VARCHAR, at TIMESTAMP,
+		value DOUBLE, price DECIMAL(9,2), tag UUID)`))
+
+	appender, err := tester.conn.AppenderCreate("", "metrics")
+	assert.Nil(t, err)
+
+	rows := int(VectorSize())*2 + 5
+	ids := make([]int32, rows)
+	hosts := make([]string, rows)
+	ats := make([]time.Time, rows)
+	values := make([]float64, rows)
+	valid := make([]bool, rows)
+	prices := make([]*float64, rows)
+	start := time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+	for i := range ids {
+		ids[i] = int32(i)
+		hosts[i] = fmt.Sprintf("host-%d", i%3)
+		ats[i] = start.Add(time.Duration(i) * time.Second)
+		values[i] = float64(i) / 2
+		valid[i] = i%10 != 0
+		if i%2 == 0 {
+			price := float64(i) + 0.125
+			prices[i] = &price
+		}
+	}
+	tags := make([]UUID, rows)
+
+	assert.Nil(t, appender.AppendRow(-1, "first", start, 0, nil, nil))
+	assert.Nil(t, appender.AppendColumns(ids, hosts, ats, NullableColumn{Values: values, Valid: valid}, prices, tags))
+
+	assert.ErrorIs(t, appender.AppendColumns(ids, hosts), ErrAppenderColumnMismatch)
+	assert.ErrorIs(t, appender.AppendColumns(ids, hosts[:1], ats, values, prices, tags), ErrAppenderColumnMismatch)
+	assert.ErrorIs(t, appender.AppendColumns(ids, hosts, ats, NullableColumn{Values: values, Valid: valid[:1]}, prices, tags), ErrAppenderColumnMismatch)
+	err = appender.AppendColumns(ids, ids, ats, values, prices, tags)
+	assert.ErrorIs(t, err, ErrVectorTypeMismatch)
+	assert.Contains(t, err.Error(), "column host")
+	// a bad value in the first chunk appends nothing
+	mixed := make([]any, rows)
+	for i := range mixed {
+		mixed[i] = hosts[i]
+	}
+	mixed[3] = 1
+	err = appender.AppendColumns(ids, mixed, ats, values, prices, tags)
+	assert.ErrorIs(t, err, ErrVectorTypeMismatch)
+	assert.Contains(t, err.Error(), fmt.Sprintf("row %d", rows+4))
+	assert.Nil(t, appender.Destroy())
+
+	var result Result
+	assert.Nil(t, tester.conn.Query(`SELECT count(*), min(id), max(id), count(value), count(price), max(at),
+		sum(value), max(price)::VARCHAR, count(DISTINCT host) FROM metrics`, &result))
+	defer result.Destroy()
+	assert.Equal(t, int64(rows+1), result.ValueInt64(0, 0))
+	assert.Equal(t, int32(-1), result.ValueInt32(1, 0))
+	assert.Equal(t, int32(rows-1), result.ValueInt32(2, 0))
+	assert.Equal(t, int64(rows+1-(rows+9)/10), result.ValueInt64(3, 0))
+	assert.Equal(t, int64((rows+1)/2), result.ValueInt64(4, 0))
+	maxAt := result.ValueTimestamp(5, 0)
+	assert.Equal(t, ats[rows-1], maxAt.Time())
+	assert.Equal(t, fmt.Sprintf("%d.13", rows-1-(rows-1)%2), result.ValueVarChar(7, 0))
+	assert.Equal(t, int64(4), result.ValueInt64(8, 0))
+}
+
+func benchmarkAppender(b *testing.B, appendRows func(a *Appender, ids []int64, names []string, values []float64) error) {
+	var tester CAPITester
+	if !tester.OpenDatabase("") {
+		b.Fatal("open database")
+	}
+	defer tester.CleanUp()
+	if err := tester.NoResultQuery("CREATE TABLE bench (id BIGINT, name VARCHAR, value DOUBLE)"); err != nil {
+		b.Fatal(err)
+	}
+	const rows = 100000
+	ids := make([]int64, rows)
+	names := make([]string, rows)
+	values := make([]float64, rows)
+	for i := range ids {
+		ids[i] = int64(i)
+		names[i] = fmt.Sprintf("name-%d", i)
+		values[i] = float64(i)
+	}
+
+	b.ResetTimer()
+	start := time.Now()
+	for n := 0; n < b.N; n++ {
+		appender, err := tester.conn.AppenderCreate("", "bench")
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := appendRows(appender, ids, names, values); err != nil {
+			b.Fatal(err)
+		}
+		if err := appender.Destroy(); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(rows*b.N)/time.Since(start).Seconds(), "rows/s")
+}
+
+func BenchmarkAppenderRows(b *testing.B) {
+	benchmarkAppender(b, func(a *Appender, ids []int64, names []string, values []float64) error {
+		for i := range ids {
+			if err := a.BeginRow(); err != nil {
+				return err
+			}
+			if err := a.AppendInt64(ids[i]); err != nil {
+				return err
+			}
+			if err := a.AppendVarChar(names[i]); err != nil {
+				return err
+			}
+			if err := a.AppendDouble(Double(values[i])); err != nil {
+				return err
+			}
+			if err := a.EndRow(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func BenchmarkAppenderColumns(b *testing.B) {
+	benchmarkAppender(b, func(a *Appender, ids []int64, names []string, values []float64) error {
+		return a.AppendColumns(ids, names, values)
+	})
+}
+
+func TestAppendColumnsErrorInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+	assert.Nil(t, tester.NoResultQuery(`CREATE TABLE counters (n INTEGER)`))
+
+	appender, err := tester.conn.AppenderCreate("", "counters")
+	assert.Nil(t, err)
+	size := int(VectorSize())
+	assert.Nil(t, appender.AppendRow(-1))
+	counts := make([]int64, 2*size)
+	for i := range counts {
+		counts[i] = int64(i)
+	}
+	counts[size+3] = 1 << 40
+	// the first chunk is appended before the overflow in the second
+	err = appender.AppendColumns(counts)
+	assert.ErrorIs(t, err, ErrVectorOutOfRange)
+	assert.Contains(t, err.Error(), fmt.Sprintf("row %d", 1+size+3))
+	assert.Nil(t, appender.Destroy())
+
+	var result Result
+	assert.Nil(t, tester.conn.Query(`SELECT count(*), max(n) FROM counters`, &result))
+	defer result.Destroy()
+	assert.Equal(t, int64(1+size), result.ValueInt64(0, 0))
+	assert.Equal(t, int32(size-1), result.ValueInt32(1, 0))
+}
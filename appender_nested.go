@@ -0,0 +1,38 @@
+package duckdbcapi
+
+import "fmt"
+
+// AppendStruct appends v, a Go struct or a map with string keys, as one row.
+// Fields are matched to columns like in ScanStructs and columns without a
+// field are NULL. STRUCT columns take nested structs or maps, converted like
+// in AppendRow; the row is staged the same way.
+func (a *Appender) AppendStruct(v any) error {
+	if err := a.loadColumns(); err != nil {
+		return err
+	}
+	v, err := indirectValue(v)
+	if err != nil {
+		return err
+	}
+	field, err := structFields(v)
+	if err != nil {
+		return fmt.Errorf("%w: cannot append %T as a row", ErrVectorTypeMismatch, v)
+	}
+	values := make([]any, len(a.names))
+	for i, name := range a.names {
+		values[i], _ = field(name)
+	}
+	return a.stageRow(values)
+}
+
+// AppendList always returns ErrVectorListUnsupported: the v0.3.4 C API can
+// neither append a LIST value nor grow the child vector of a LIST column.
+func (a *Appender) AppendList(v any) error {
+	return fmt.Errorf("%w: cannot append %T to a LIST column", ErrVectorListUnsupported, v)
+}
+
+// AppendMap always returns ErrVectorListUnsupported, since MAP values are
+// stored as LISTs.
+func (a *Appender) AppendMap(v any) error {
+	return fmt.Errorf("%w: cannot append %T to a MAP column", ErrVectorListUnsupported, v)
+}
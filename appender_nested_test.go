@@ -0,0 +1,50 @@
+package duckdbcapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendStructInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+	assert.Nil(t, tester.NoResultQuery(`CREATE TABLE events (id INTEGER,
+		attrs STRUCT(source VARCHAR, at TIMESTAMP), tags VARCHAR[])`))
+
+	appender, err := tester.conn.AppenderCreate("", "events")
+	assert.Nil(t, err)
+
+	type attrs struct {
+		Source string
+		At     *time.Time
+	}
+	type event struct {
+		ID    int32 `duckdb:"id"`
+		Attrs attrs
+	}
+	at := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+	assert.Nil(t, appender.AppendStruct(event{ID: 1, Attrs: attrs{Source: "web", At: &at}}))
+	assert.Nil(t, appender.AppendStruct(&event{ID: 2}))
+	assert.Nil(t, appender.AppendStruct(map[string]any{"id": 3, "attrs": map[string]any{"source": "batch"}}))
+	assert.ErrorIs(t, appender.AppendStruct(4), ErrVectorTypeMismatch)
+	assert.ErrorIs(t, appender.AppendStruct(map[string]any{"id": 4, "tags": []string{"a"}}), ErrVectorListUnsupported)
+	assert.ErrorIs(t, appender.AppendList([]string{"a"}), ErrVectorListUnsupported)
+	assert.ErrorIs(t, appender.AppendMap(map[string]int{"a": 1}), ErrVectorListUnsupported)
+	assert.Nil(t, appender.Destroy())
+
+	var result Result
+	assert.Nil(t, tester.conn.Query(`SELECT id, attrs.source, attrs.at, attrs.at IS NULL, tags IS NULL
+		FROM events ORDER BY id`, &result))
+	defer result.Destroy()
+	assert.Equal(t, uint64(3), result.RowCount())
+	assert.Equal(t, "web", result.ValueVarChar(1, 0))
+	ts := result.ValueTimestamp(2, 0)
+	assert.Equal(t, at, ts.Time())
+	assert.Equal(t, "", result.ValueVarChar(1, 1))
+	assert.Equal(t, true, result.ValueBoolean(3, 1))
+	assert.Equal(t, "batch", result.ValueVarChar(1, 2))
+	assert.Equal(t, true, result.ValueBoolean(4, 2))
+}
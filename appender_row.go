@@ -0,0 +1,137 @@
+package duckdbcapi
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrAppenderColumnMismatch = errors.New("ErrAppenderColumnMismatch")
+
+// AppendRow appends one row, converting each value to the type of its
+// column: Go integers, floats, strings, []byte, bool, time.Time,
+// time.Duration, UUID, Decimal, HugeInt, *big.Int and the package's
+// temporal types, structs or map[string]any for STRUCT columns. nil and nil
+// pointers append NULL. The number of values must match the table. The
+// v0.3.4 C API cannot fill LIST vectors, so LIST and MAP columns only accept
+// NULL and empty values and return ErrVectorListUnsupported for any other.
+//
+// Rows appended this way are staged in a DataChunk and handed to DuckDB once
+// VectorSize rows are staged, on Flush, Close, Destroy and before the next
+// BeginRow or AppendDataChunk, so they stay in order with the other rows.
+func (a *Appender) AppendRow(values ...any) error {
+	if err := a.loadColumns(); err != nil {
+		return err
+	}
+	if len(values) != len(a.types) {
+		return fmt.Errorf("%w: table has %d columns, got %d values", ErrAppenderColumnMismatch, len(a.types), len(values))
+	}
+	return a.stageRow(values)
+}
+
+// ColumnNames returns the column names of the appender's table.
+func (a *Appender) ColumnNames() ([]string, error) {
+	if err := a.loadColumns(); err != nil {
+		return nil, err
+	}
+	return append([]string{}, a.names...), nil
+}
+
+// loadColumns looks up the column names and types of the table, which the
+// v0.3.4 C API does not expose for an appender.
+func (a *Appender) loadColumns() error {
+	if a.types != nil {
+		return nil
+	}
+	if a.conn == nil {
+		return fmt.Errorf("%w: appender was not created by Connection.AppenderCreate", ErrAppenderColumnMismatch)
+	}
+	table := quoteIdentifier(a.table)
+	if a.schema != "" {
+		table = quoteIdentifier(a.schema) + "." + table
+	}
+	var res Result
+	defer res.Destroy()
+	if err := a.conn.Query("SELECT * FROM "+table+" LIMIT 0", &res); err != nil {
+		return err
+	}
+	count := res.ColumnCount()
+	a.names = make([]string, count)
+	a.types = make([]*LogicalType, count)
+	for i := uint64(0); i < count; i++ {
+		a.names[i], _ = res.ColumnName(i)
+		a.types[i] = res.ColumnLogicalType(i)
+	}
+	return nil
+}
+
+// stageRow writes values to the next row of the staged chunk.
+func (a *Appender) stageRow(values []any) error {
+	if a.chunk == nil {
+		chunk, err := CreateDataChunk(a.types, uint64(len(a.types)))
+		if err != nil {
+			return err
+		}
+		a.chunk = chunk
+	}
+	if a.writers == nil {
+		writers := make([]*cellWriter, len(a.types))
+		for i := range writers {
+			vec, err := a.chunk.GetVector(uint64(i))
+			if err == nil {
+				writers[i], err = newCellWriter(vec)
+			}
+			if err != nil {
+				destroyCellWriters(writers)
+				return err
+			}
+		}
+		a.writers = writers
+	}
+	for i, v := range values {
+		if err := a.writers[i].set(a.staged, v); err != nil {
+			return fmt.Errorf("row %d, column %s: %w", a.rows, a.names[i], err)
+		}
+	}
+	a.staged++
+	a.rows++
+	if a.staged == VectorSize() {
+		return a.flushStaged()
+	}
+	return nil
+}
+
+// flushStaged appends the staged rows and resets the chunk. The writers are
+// rebuilt for the next row since resetting may replace the vector buffers.
+func (a *Appender) flushStaged() error {
+	if a.staged == 0 {
+		return nil
+	}
+	a.chunk.SetSize(a.staged)
+	err := a.appendChunk(a.chunk)
+	a.chunk.Reset()
+	destroyCellWriters(a.writers)
+	a.writers = nil
+	a.staged = 0
+	return err
+}
+
+func (a *Appender) destroyStaged() {
+	destroyCellWriters(a.writers)
+	a.writers = nil
+	if a.chunk != nil {
+		a.chunk.Destroy()
+		a.chunk = nil
+	}
+	for _, lt := range a.types {
+		lt.Destroy()
+	}
+	a.types = nil
+}
+
+func destroyCellWriters(writers []*cellWriter) {
+	for _, w := range writers {
+		if w != nil {
+			w.destroy()
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package duckdbcapi
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendRowInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+	assert.Nil(t, tester.NoResultQuery(`CREATE TABLE events (id INTEGER, name VARCHAR, ts TIMESTAMP,
+		price DECIMAL(9,2), total HUGEINT, uid UUID, payload STRUCT(kind VARCHAR, size INTEGER))`))
+
+	appender, err := tester.conn.AppenderCreate("", "events")
+	assert.Nil(t, err)
+
+	names, err := appender.ColumnNames()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"id", "name", "ts", "price", "total", "uid", "payload"}, names)
+
+	ts := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+	uid, _ := ParseUUID("a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11")
+	price, _ := ParseDecimal("12.345", 10, 3)
+	total, _ := new(big.Int).SetString("170141183460469231731687303715884105727", 10)
+	assert.Nil(t, appender.AppendRow(1, "first", ts, price, total, uid, map[string]any{"kind": "a", "size": 3}))
+	assert.Nil(t, appender.AppendRow(int64(2), nil, (*time.Time)(nil), "0.5", 7, uid.String(), nil))
+
+	// rows appended through the row API stay in order with staged rows
+	assert.Nil(t, appender.BeginRow())
+	assert.Nil(t, appender.AppendInt32(3))
+	for i := 0; i < 6; i++ {
+		assert.Nil(t, appender.AppendNull())
+	}
+	assert.Nil(t, appender.EndRow())
+
+	assert.ErrorIs(t, appender.AppendRow(1, "x"), ErrAppenderColumnMismatch)
+	err = appender.AppendRow(1<<40, "x", ts, price, 1, uid, nil)
+	assert.ErrorIs(t, err, ErrVectorOutOfRange)
+	assert.Contains(t, err.Error(), "column id")
+	assert.ErrorIs(t, appender.AppendRow(4, 4, ts, price, 1, uid, nil), ErrVectorTypeMismatch)
+	assert.Nil(t, appender.AppendRow(4, "last", ts, 1.25, 1, uid, struct {
+		Kind string
+		Size *int
+	}{Kind: "b"}))
+	assert.Nil(t, appender.Destroy())
+
+	var result Result
+	assert.Nil(t, tester.conn.Query(`SELECT id, name, ts, price::VARCHAR, total::VARCHAR, uid::VARCHAR,
+		payload.kind, payload.size FROM events`, &result))
+	defer result.Destroy()
+	assert.Equal(t, uint64(4), result.RowCount())
+	for row, id := range []int32{1, 2, 3, 4} {
+		assert.Equal(t, id, result.ValueInt32(0, uint64(row)))
+	}
+	assert.Equal(t, "first", result.ValueVarChar(1, 0))
+	got := result.ValueTimestamp(2, 0)
+	assert.Equal(t, ts, got.Time())
+	assert.Equal(t, "12.35", result.ValueVarChar(3, 0))
+	assert.Equal(t, total.String(), result.ValueVarChar(4, 0))
+	assert.Equal(t, uid.String(), result.ValueVarChar(5, 0))
+	assert.Equal(t, "a", result.ValueVarChar(6, 0))
+	assert.Equal(t, int32(3), result.ValueInt32(7, 0))
+
+	assert.Equal(t, true, result.ValueIsNull(1, 1))
+	assert.Equal(t, true, result.ValueIsNull(2, 1))
+	assert.Equal(t, "0.50", result.ValueVarChar(3, 1))
+	assert.Equal(t, true, result.ValueIsNull(6, 1))
+	assert.Equal(t, true, result.ValueIsNull(1, 2))
+	assert.Equal(t, "1.25", result.ValueVarChar(3, 3))
+	assert.Equal(t, "b", result.ValueVarChar(6, 3))
+	assert.Equal(t, true, result.ValueIsNull(7, 3))
+}
+
+func TestAppendRowDataChunkOrderInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+	assert.Nil(t, tester.NoResultQuery("CREATE TABLE ids (id BIGINT, name VARCHAR)"))
+
+	appender, err := tester.conn.AppenderCreate("", "ids")
+	assert.Nil(t, err)
+	assert.Nil(t, appender.AppendRow(1, "staged"))
+
+	types := []*LogicalType{CreateLogicalType(DuckDBTypeBigInt), CreateLogicalType(DuckDBTypeVarChar)}
+	defer types[0].Destroy()
+	defer types[1].Destroy()
+	chunk, err := CreateDataChunk(types, 2)
+	assert.Nil(t, err)
+	defer chunk.Destroy()
+	ids, _ := chunk.GetVector(0)
+	names, _ := chunk.GetVector(1)
+	assert.Nil(t, NewVectorWriter(ids).SetInt64s([]int64{2}))
+	assert.Nil(t, NewVectorWriter(names).SetStrings([]string{"chunk"}))
+	chunk.SetSize(1)
+	assert.Nil(t, appender.AppendDataChunk(chunk))
+
+	assert.Nil(t, appender.BeginRow())
+	assert.Nil(t, appender.AppendInt64(3))
+	assert.Nil(t, appender.AppendVarCharLength("row api", 3))
+	assert.Nil(t, appender.EndRow())
+	assert.Nil(t, appender.Destroy())
+
+	var result Result
+	assert.Nil(t, tester.conn.Query("SELECT id, name FROM ids", &result))
+	defer result.Destroy()
+	assert.Equal(t, uint64(3), result.RowCount())
+	for row, name := range []string{"staged", "chunk", "row"} {
+		assert.Equal(t, int64(row+1), result.ValueInt64(0, uint64(row)))
+		assert.Equal(t, name, result.ValueVarChar(1, uint64(row)))
+	}
+}
+
+func TestStructAppenderInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+	assert.Nil(t, tester.NoResultQuery(`CREATE TABLE users (id BIGINT, name VARCHAR, created DATE,
+		score DOUBLE, address STRUCT(city VARCHAR, zip INTEGER), note VARCHAR)`))
+
+	appender, err := tester.conn.AppenderCreate("", "users")
+	assert.Nil(t, err)
+
+	type address struct {
+		City string
+		Zip  int `duckdb:"zip"`
+	}
+	type base struct {
+		ID int64 `duckdb:"id"`
+	}
+	type user struct {
+		base
+		Name    *string
+		Created time.Time
+		Score   float32
+		Address *address
+		Skipped string `duckdb:"-"`
+	}
+	users, err := NewStructAppender[user](appender)
+	assert.Nil(t, err)
+
+	name := "alice"
+	day := time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+	rows := make([]user, 0, VectorSize()+10)
+	for i := 0; i < cap(rows); i++ {
+		rows = append(rows, user{base: base{ID: int64(i)}, Created: day, Score: 1.5})
+	}
+	rows[0].Name = &name
+	rows[0].Address = &address{City: "Berlin", Zip: 10115}
+	assert.Nil(t, users.Append(rows...))
+
+	_, err = NewStructAppender[struct {
+		ID string `duckdb:"id"`
+	}](appender)
+	assert.ErrorIs(t, err, ErrAppenderColumnMismatch)
+	_, err = NewStructAppender[struct {
+		Missing int `duckdb:"missing"`
+	}](appender)
+	assert.ErrorIs(t, err, ErrAppenderColumnMismatch)
+	_, err = NewStructAppender[int](appender)
+	assert.ErrorIs(t, err, ErrScanStructType)
+	assert.Nil(t, appender.Destroy())
+
+	var result Result
+	assert.Nil(t, tester.conn.Query(`SELECT count(*), max(id), count(name), count(address), count(note),
+		min(created), min(score) FROM users`, &result))
+	defer result.Destroy()
+	assert.Equal(t, int64(cap(rows)), result.ValueInt64(0, 0))
+	assert.Equal(t, int64(cap(rows)-1), result.ValueInt64(1, 0))
+	assert.Equal(t, int64(1), result.ValueInt64(2, 0))
+	assert.Equal(t, int64(1), result.ValueInt64(3, 0))
+	assert.Equal(t, int64(0), result.ValueInt64(4, 0))
+	d := result.ValueDate(5, 0)
+	assert.Equal(t, day, d.Time())
+	assert.Equal(t, Double(1.5), result.ValueDouble(6, 0))
+
+	var first Result
+	assert.Nil(t, tester.conn.Query("SELECT name, address.city, address.zip FROM users WHERE id = 0", &first))
+	defer first.Destroy()
+	assert.Equal(t, "alice", first.ValueVarChar(0, 0))
+	assert.Equal(t, "Berlin", first.ValueVarChar(1, 0))
+	assert.Equal(t, int32(10115), first.ValueInt32(2, 0))
+}
+
+func TestAppendRowListColumnsInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+	assert.Nil(t, tester.NoResultQuery("CREATE TABLE events (id INTEGER, tags VARCHAR[], counts MAP(VARCHAR, INTEGER))"))
+
+	appender, err := tester.conn.AppenderCreate("", "events")
+	assert.Nil(t, err)
+	assert.Nil(t, appender.AppendRow(1, nil, nil))
+	assert.Nil(t, appender.AppendRow(2, []string{}, map[string]int{}))
+	err = appender.AppendRow(3, []string{"a"}, nil)
+	assert.ErrorIs(t, err, ErrVectorListUnsupported)
+	assert.Contains(t, err.Error(), "column tags")
+	assert.ErrorIs(t, appender.AppendRow(3, nil, map[string]int{"clicks": 3}), ErrVectorListUnsupported)
+	assert.ErrorIs(t, appender.AppendColumns([]int32{3}, [][]string{{"a"}}, []map[string]int{nil}), ErrVectorListUnsupported)
+	assert.Nil(t, appender.Destroy())
+
+	var result Result
+	assert.Nil(t, tester.conn.Query("SELECT id, tags IS NULL, len(tags) FROM events ORDER BY id", &result))
+	defer result.Destroy()
+	assert.Equal(t, uint64(2), result.RowCount())
+	assert.Equal(t, true, result.ValueBoolean(1, 0))
+	assert.Equal(t, int64(0), result.ValueInt64(2, 1))
+}
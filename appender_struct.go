@@ -0,0 +1,67 @@
+package duckdbcapi
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// StructAppender appends Go structs as rows of an Appender's table. Columns
+// are matched to fields by `duckdb:"col"` tag or case-insensitive field
+// name like in ScanStructs; columns without a field are appended as NULL.
+type StructAppender[T any] struct {
+	a      *Appender
+	fields [][]int
+	values []any
+}
+
+// NewStructAppender maps the fields of T to the columns of a's table and
+// checks once that every mapped field type can be written to its column. A
+// `duckdb` tag naming a column the table does not have is an error.
+func NewStructAppender[T any](a *Appender) (*StructAppender[T], error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %s is not a struct", ErrScanStructType, t)
+	}
+	if err := a.loadColumns(); err != nil {
+		return nil, err
+	}
+	plan := structPlanFor(t)
+	columns := make(map[string]bool, len(a.names))
+	s := &StructAppender[T]{a: a, fields: make([][]int, len(a.names)), values: make([]any, len(a.names))}
+	for i, name := range a.names {
+		columns[name] = true
+		index := plan.lookup(name)
+		if index == nil {
+			continue
+		}
+		field := t.FieldByIndex(index)
+		if !cellAccepts(field.Type, a.types[i]) {
+			return nil, fmt.Errorf("%w: field %s of type %s cannot be appended to column %s %s",
+				ErrAppenderColumnMismatch, field.Name, field.Type, name, a.types[i])
+		}
+		s.fields[i] = index
+	}
+	for tag := range plan.byTag {
+		if !columns[tag] {
+			return nil, fmt.Errorf("%w: table has no column %s", ErrAppenderColumnMismatch, tag)
+		}
+	}
+	return s, nil
+}
+
+// Append appends rows in order. It stops at the first row that fails.
+func (s *StructAppender[T]) Append(rows ...T) error {
+	for i := range rows {
+		rv := reflect.ValueOf(&rows[i]).Elem()
+		for col, index := range s.fields {
+			s.values[col] = nil
+			if index != nil {
+				s.values[col] = rv.FieldByIndex(index).Interface()
+			}
+		}
+		if err := s.a.stageRow(s.values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -21,7 +21,7 @@ func TestAppenderStatementsInCAPI(t *testing.T) {
 	assert.Nil(t, tester.NoResultQuery("CREATE TABLE test (i INTEGER, d double, s string)"))
 
 	appender, err := tester.conn.AppenderCreate("", "nonexistant-table")
-	assert.Equal(t, ErrDuckDBError, err)
+	assert.ErrorIs(t, err, ErrDuckDBError)
 
 	assert.NotEqual(t, uintptr(0), uintptr(appender.c))
 	assert.NotEqual(t, "", appender.Error())
@@ -37,7 +37,7 @@ func TestAppenderStatementsInCAPI(t *testing.T) {
 	assert.Nil(t, appender.AppendVarChar("Hello, World"))
 
 	// out of cols here
-	assert.Equal(t, ErrDuckDBError, appender.AppendInt32(42))
+	assert.ErrorIs(t, appender.AppendInt32(42), ErrDuckDBError)
 	assert.Nil(t, appender.EndRow())
 	assert.Nil(t, appender.Flush())
 
@@ -45,12 +45,12 @@ func TestAppenderStatementsInCAPI(t *testing.T) {
 	assert.Nil(t, appender.AppendInt32(42))
 	assert.Nil(t, appender.AppendDouble(4.2))
 	// not enough cols here
-	assert.Equal(t, ErrDuckDBError, appender.EndRow())
+	assert.ErrorIs(t, appender.EndRow(), ErrDuckDBError)
 	assert.NotEqual(t, "", appender.Error())
 
 	assert.Nil(t, appender.AppendVarChar("Hello, World"))
 	// out of cols here
-	assert.Equal(t, ErrDuckDBError, appender.AppendInt32(42))
+	assert.ErrorIs(t, appender.AppendInt32(42), ErrDuckDBError)
 	assert.NotEqual(t, "", appender.Error())
 	assert.Nil(t, appender.EndRow())
 	// we can flush again why not
@@ -65,12 +65,12 @@ func TestAppenderStatementsInCAPI(t *testing.T) {
 
 	assert.Nil(t, appender.Destroy())
 	// this has been destroyed
-	assert.Equal(t, ErrDuckDBError, appender.Close())
+	assert.ErrorIs(t, appender.Close(), ErrDuckDBError)
 	assert.Equal(t, "", appender.Error())
-	assert.Equal(t, ErrDuckDBError, appender.Flush())
-	assert.Equal(t, ErrDuckDBError, appender.EndRow())
-	assert.Equal(t, ErrDuckDBError, appender.AppendInt32(42))
-	assert.Equal(t, ErrDuckDBError, appender.Destroy())
+	assert.ErrorIs(t, appender.Flush(), ErrDuckDBError)
+	assert.ErrorIs(t, appender.EndRow(), ErrDuckDBError)
+	assert.ErrorIs(t, appender.AppendInt32(42), ErrDuckDBError)
+	assert.ErrorIs(t, appender.Destroy(), ErrDuckDBError)
 
 	// many types
 	qStr := `CREATE TABLE many_types(bool boolean, t TINYINT, s SMALLINT, b BIGINT, ut UTINYINT,
@@ -91,6 +91,7 @@ func TestAppenderStatementsInCAPI(t *testing.T) {
 	assert.Nil(t, appender.AppendUInt64(1))
 	assert.Nil(t, appender.AppendFloat(0.5))
 	assert.Nil(t, appender.AppendDouble(0.5))
+	assert.ErrorIs(t, appender.AppendVarCharLength("hello", 6), ErrVarCharLength)
 	assert.Nil(t, appender.AppendVarCharLength("hello world", 5))
 
 	ds := InitDateStruct(1992, 9, 3)
@@ -80,10 +80,10 @@ func TestDataChunkCAPI(t *testing.T) {
 	assert.Equal(t, uint64(1), dataChunk.GetSize())
 
 	assert.Nil(t, appender.AppendDataChunk(dataChunk))
-	assert.Equal(t, ErrDuckDBError, appender.AppendDataChunk(&DataChunk{}))
+	assert.ErrorIs(t, appender.AppendDataChunk(&DataChunk{}), ErrDuckDBError)
 	{
 		var _app Appender
-		assert.Equal(t, ErrDuckDBError, _app.AppendDataChunk(dataChunk))
+		assert.ErrorIs(t, _app.AppendDataChunk(dataChunk), ErrDuckDBError)
 	}
 	// append nulls
 	dataChunk.Reset()
@@ -20,7 +20,7 @@ func TestPreparedStatementsInCAPI(t *testing.T) {
 	t.Run("BindBoolean", func(t *testing.T) {
 		var result Result
 		assert.Nil(t, stmt.BindBoolean(1, true))
-		assert.Equal(t, ErrDuckDBError, stmt.BindBoolean(2, true))
+		assert.ErrorIs(t, stmt.BindBoolean(2, true), ErrDuckDBError)
 		assert.Nil(t, stmt.ExecutePrepared(&result))
 		defer result.Destroy()
 		assert.Equal(t, int64(1), result.ValueInt64(0, 0))
@@ -106,7 +106,7 @@ func TestPreparedStatementsInCAPI(t *testing.T) {
 	})
 	t.Run("BindVarChar", func(t *testing.T) {
 		var result Result
-		assert.Equal(t, ErrDuckDBError, stmt.BindVarChar(1, "\x80\x40\x41"))
+		assert.ErrorIs(t, stmt.BindVarChar(1, "\x80\x40\x41"), ErrDuckDBError)
 		assert.Nil(t, stmt.BindVarChar(1, "44"))
 		assert.Nil(t, stmt.ExecutePrepared(&result))
 		defer result.Destroy()
@@ -126,7 +126,8 @@ func TestPreparedStatementsInCAPI(t *testing.T) {
 	assert.Nil(t, tester.conn.Prepare("SELECT CAST($1 AS VARCHAR)", &stmt))
 	t.Run("BindVarCharLength", func(t *testing.T) {
 		var result Result
-		assert.Equal(t, ErrDuckDBError, stmt.BindVarCharLength(1, "\x80\x40\x41", 3))
+		assert.ErrorIs(t, stmt.BindVarCharLength(1, "\x80\x40\x41", 3), ErrDuckDBError)
+		assert.ErrorIs(t, stmt.BindVarCharLength(1, "hello", 6), ErrVarCharLength)
 		assert.Nil(t, stmt.BindVarCharLength(1, "hello world", 5))
 		assert.Nil(t, stmt.ExecutePrepared(&result))
 		defer result.Destroy()
@@ -205,11 +206,11 @@ func TestPreparedStatementsInCAPI(t *testing.T) {
 
 	})
 	t.Run("not-so-happy path", func(t *testing.T) {
-		assert.Equal(t, ErrDuckDBError, tester.conn.Prepare("SELECT XXXXX", &stmt))
+		assert.ErrorIs(t, tester.conn.Prepare("SELECT XXXXX", &stmt), ErrDuckDBError)
 		stmt.Destroy()
 		assert.Nil(t, tester.conn.Prepare("SELECT CAST($1 AS INTEGER)", &stmt))
 		var result Result
-		assert.Equal(t, ErrDuckDBError, stmt.ExecutePrepared(&result))
+		assert.ErrorIs(t, stmt.ExecutePrepared(&result), ErrDuckDBError)
 		result.Destroy()
 		stmt.Destroy()
 	})
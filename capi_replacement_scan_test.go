@@ -60,5 +60,5 @@ func TestReplacementScanInCAPI(t *testing.T) {
 	assert.Equal(t, int64(2), result.FetchValueInt64(0, 2))
 	result.Destroy()
 
-	assert.Equal(t, ErrDuckDBError, tester.Query("SELECT * FROM nonexistant", &result))
+	assert.ErrorIs(t, tester.Query("SELECT * FROM nonexistant", &result), ErrDuckDBError)
 }
@@ -193,7 +193,7 @@ func TestTableFunctionErrorsInCAPI(t *testing.T) {
 	cAPIRegisterTableFunction(t, tester.conn, "my_error_init", &myErrorInit{&tableFunctionCallback{t}})
 	cAPIRegisterTableFunction(t, tester.conn, "my_error_function", &myErrorFunction{&tableFunctionCallback{t}})
 	var result CAPIResult
-	assert.Equal(t, ErrDuckDBError, tester.Query("SELECT * FROM my_error_bind(1)", &result))
-	assert.Equal(t, ErrDuckDBError, tester.Query("SELECT * FROM my_error_init(1)", &result))
-	assert.Equal(t, ErrDuckDBError, tester.Query("SELECT * FROM my_error_function(1)", &result))
+	assert.ErrorIs(t, tester.Query("SELECT * FROM my_error_bind(1)", &result), ErrDuckDBError)
+	assert.ErrorIs(t, tester.Query("SELECT * FROM my_error_init(1)", &result), ErrDuckDBError)
+	assert.ErrorIs(t, tester.Query("SELECT * FROM my_error_function(1)", &result), ErrDuckDBError)
 }
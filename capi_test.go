@@ -343,9 +343,9 @@ func TestErrosInCAPI(t *testing.T) {
 	// fail prepare API calls
 	conn := Connection{c: nil}
 	stmt := PreparedStatement{}
-	assert.Equal(t, ErrDuckDBError, conn.Prepare("SELECT 42", &stmt))
-	assert.Equal(t, ErrDuckDBError, conn.Prepare("", &stmt))
-	assert.Equal(t, ErrDuckDBError, tester.conn.Prepare("SELECT * from INVALID_TABLE", &stmt))
+	assert.ErrorIs(t, conn.Prepare("SELECT 42", &stmt), ErrDuckDBError)
+	assert.ErrorIs(t, conn.Prepare("", &stmt), ErrDuckDBError)
+	assert.ErrorIs(t, tester.conn.Prepare("SELECT * from INVALID_TABLE", &stmt), ErrDuckDBError)
 	defer stmt.Destroy()
 	assert.NotNil(t, stmt.PrepareError())
 	assert.NotNil(t, stmt.c)
@@ -400,12 +400,12 @@ func TestCAPIConfig(t *testing.T) {
 	// open the database & connection
 	// cannot open an in-memory database in read-only mode
 	_, errStr, err := OpenExt(":memory:", cfg)
-	assert.Equal(t, ErrDuckDBError, err)
+	assert.ErrorIs(t, err, ErrDuckDBError)
 	assert.Equal(t, true, len(errStr) > 0)
 
 	// cannot open a database that does not exist
 	_, errStr, err = OpenExt(dbPath, cfg)
-	assert.Equal(t, ErrDuckDBError, err)
+	assert.ErrorIs(t, err, ErrDuckDBError)
 	assert.Equal(t, true, len(errStr) > 0)
 
 	// we can create the database and add some tables
@@ -445,7 +445,7 @@ func TestCAPIConfig(t *testing.T) {
 
 	// api abuse
 	_, _, err = GetConfigFlag(9999999)
-	assert.Equal(t, ErrDuckDBError, err)
+	assert.ErrorIs(t, err, ErrDuckDBError)
 
 }
 func TestIssue2058(t *testing.T) {
@@ -465,6 +465,6 @@ func TestIssue2058(t *testing.T) {
 	assert.Nil(t, conn.Query("SELECT count(*) FROM integers;", resultCount))
 	resultCount.Destroy()
 	result := &Result{}
-	assert.Equal(t, ErrDuckDBError, conn.Query("non valid SQL", result))
+	assert.ErrorIs(t, conn.Query("non valid SQL", result), ErrDuckDBError)
 	result.Destroy() // segmentation failure happens here
 }
@@ -0,0 +1,81 @@
+package duckdbcapi
+
+// ChunkIterator hands out the DataChunks of a query result one at a time and
+// destroys each chunk when the next one is fetched or the iterator is closed.
+//
+// It does not stream: the DuckDB v0.3.4 C API has no streaming query
+// results, so the whole result is materialized by DuckDB before the first
+// chunk is returned. ChunkIterator only keeps the Go side from holding more
+// than one chunk at a time.
+type ChunkIterator struct {
+	res    Result
+	next   uint64
+	chunk  *DataChunk
+	closed bool
+}
+
+// QueryChunks runs query and iterates over the chunks of its materialized
+// result.
+func (c *Connection) QueryChunks(query string) (*ChunkIterator, error) {
+	s := &ChunkIterator{}
+	if err := c.Query(query, &s.res); err != nil {
+		s.res.Destroy()
+		return nil, err
+	}
+	return s, nil
+}
+
+// ExecuteChunks executes the statement and iterates over the chunks of its
+// materialized result.
+func (p *PreparedStatement) ExecuteChunks() (*ChunkIterator, error) {
+	s := &ChunkIterator{}
+	if err := p.ExecutePrepared(&s.res); err != nil {
+		s.res.Destroy()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *ChunkIterator) ColumnCount() uint64 {
+	return s.res.ColumnCount()
+}
+
+func (s *ChunkIterator) ColumnName(col uint64) (string, error) {
+	return s.res.ColumnName(col)
+}
+
+func (s *ChunkIterator) ColumnLogicalType(col uint64) *LogicalType {
+	return s.res.ColumnLogicalType(col)
+}
+
+func (s *ChunkIterator) releaseChunk() {
+	if s.chunk != nil {
+		s.chunk.Destroy()
+		s.chunk = nil
+	}
+}
+
+// Next destroys the previously returned chunk and fetches the next one.
+// It returns a nil chunk once the result is exhausted.
+func (s *ChunkIterator) Next() (*DataChunk, error) {
+	s.releaseChunk()
+	if s.closed || s.next >= s.res.ChunkCount() {
+		return nil, nil
+	}
+	chunk, err := s.res.Chunk(s.next)
+	if err != nil {
+		return nil, err
+	}
+	s.next++
+	s.chunk = chunk
+	return chunk, nil
+}
+
+func (s *ChunkIterator) Close() {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	s.releaseChunk()
+	s.res.Destroy()
+}
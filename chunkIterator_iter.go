@@ -0,0 +1,23 @@
+//go:build go1.23
+
+package duckdbcapi
+
+import "iter"
+
+// All iterates over the remaining chunks and closes the iterator when the
+// loop ends. A chunk is only valid until the next iteration.
+func (s *ChunkIterator) All() iter.Seq2[*DataChunk, error] {
+	return func(yield func(*DataChunk, error) bool) {
+		defer s.Close()
+		for {
+			chunk, err := s.Next()
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if chunk == nil || !yield(chunk, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,34 @@
+//go:build go1.23
+
+package duckdbcapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunkIteratorIteratorInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+
+	it, err := tester.conn.QueryChunks("SELECT i FROM range(3000) tbl(i)")
+	assert.Nil(t, err)
+	rows := uint64(0)
+	for chunk, err := range it.All() {
+		assert.Nil(t, err)
+		rows += chunk.GetSize()
+	}
+	assert.Equal(t, uint64(3000), rows)
+	assert.Equal(t, true, it.closed)
+
+	// breaking out of the loop closes the it as well
+	it, err = tester.conn.QueryChunks("SELECT i FROM range(3000) tbl(i)")
+	assert.Nil(t, err)
+	for range it.All() {
+		break
+	}
+	assert.Equal(t, true, it.closed)
+	assert.Nil(t, it.chunk)
+}
@@ -0,0 +1,69 @@
+package duckdbcapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunkIteratorInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+
+	it, err := tester.conn.QueryChunks("SELECT i::BIGINT AS i FROM range(5000) tbl(i)")
+	assert.Nil(t, err)
+	defer it.Close()
+	assert.Equal(t, uint64(1), it.ColumnCount())
+	name, err := it.ColumnName(0)
+	assert.Nil(t, err)
+	assert.Equal(t, "i", name)
+
+	rows := uint64(0)
+	sum := int64(0)
+	chunks := 0
+	for {
+		chunk, err := it.Next()
+		assert.Nil(t, err)
+		if chunk == nil {
+			break
+		}
+		chunks++
+		assert.Equal(t, true, chunk.GetSize() <= VectorSize())
+		vec, err := chunk.GetVector(0)
+		assert.Nil(t, err)
+		pData, err := vec.GetData()
+		assert.Nil(t, err)
+		for _, v := range UnsafeSimpleDataToSlice[int64](pData, chunk.GetSize()) {
+			sum += v
+		}
+		rows += chunk.GetSize()
+	}
+	assert.Equal(t, uint64(5000), rows)
+	assert.Equal(t, int64(4999*5000/2), sum)
+	assert.Equal(t, true, chunks > 1)
+
+	// exhausted and closed iterators stay exhausted
+	chunk, err := it.Next()
+	assert.Nil(t, err)
+	assert.Nil(t, chunk)
+	it.Close()
+	it.Close()
+	chunk, err = it.Next()
+	assert.Nil(t, err)
+	assert.Nil(t, chunk)
+
+	_, err = tester.conn.QueryChunks("SELECT * FROM nonexistent")
+	assert.ErrorIs(t, err, ErrDuckDBError)
+
+	var stmt PreparedStatement
+	assert.Nil(t, tester.conn.Prepare("SELECT i FROM range($1) tbl(i)", &stmt))
+	defer stmt.Destroy()
+	assert.Nil(t, stmt.BindInt64(1, 3))
+	it2, err := stmt.ExecuteChunks()
+	assert.Nil(t, err)
+	defer it2.Close()
+	chunk, err = it2.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), chunk.GetSize())
+}
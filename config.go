@@ -4,7 +4,10 @@ package duckdbcapi
 #include <duckdb.h>
 */
 import "C"
-import "unsafe"
+import (
+	"fmt"
+	"unsafe"
+)
 
 func ConfigCount() uint64 {
 	return uint64(C.duckdb_config_count())
@@ -15,7 +18,7 @@ func GetConfigFlag(index uint64) (string, string, error) {
 	var pDescription *C.char
 
 	if C.duckdb_get_config_flag(C.ulong(index), &pName, &pDescription) == C.DuckDBError {
-		return "", "", ErrDuckDBError
+		return "", "", newError("get config flag", "", fmt.Sprintf("no config flag at index %d", index))
 	}
 
 	return C.GoString(pName), C.GoString(pDescription), nil
@@ -28,7 +31,7 @@ type Config struct {
 func CreateConfig() (*Config, error) {
 	var cfg Config
 	if C.duckdb_create_config(&cfg.c) == C.DuckDBError {
-		return nil, ErrDuckDBError
+		return nil, newError("create config", "", "")
 	}
 	return &cfg, nil
 }
@@ -43,7 +46,7 @@ func (c *Config) SetConfig(name, option string) error {
 	cOption := C.CString(option)
 	defer C.free(unsafe.Pointer(cOption))
 	if C.duckdb_set_config(c.c, cName, cOption) == C.DuckDBError {
-		return ErrDuckDBError
+		return newError("set config", "", fmt.Sprintf("cannot set %s to %q", name, option))
 	}
 	return nil
 }
@@ -4,7 +4,11 @@ package duckdbcapi
 #include <duckdb.h>
 */
 import "C"
-import "unsafe"
+import (
+	"context"
+	"fmt"
+	"unsafe"
+)
 
 type Connection struct {
 	c C.duckdb_connection
@@ -17,22 +21,78 @@ func (c *Connection) Disconnect() {
 func (c *Connection) Query(query string, result *Result) error {
 	cQuery := C.CString(query)
 	defer C.free(unsafe.Pointer(cQuery))
-	var pResult *C.duckdb_result = nil
-	if result != nil {
-		pResult = &result.c
+	if result == nil {
+		// keep a result around so the error message is not lost
+		var r Result
+		defer r.Destroy()
+		result = &r
 	}
-	if err := C.duckdb_query(c.c, cQuery, pResult); err == C.DuckDBError {
-		return ErrDuckDBError
+	if err := C.duckdb_query(c.c, cQuery, &result.c); err == C.DuckDBError {
+		return newError("query", query, result.ResultError())
 	}
 	return nil
 }
 
+// QueryContext behaves like Query but interrupts the query when ctx is
+// cancelled or its deadline passes. Cancellation is noticed between
+// execution tasks, not inside a running one. Unlike Query it only accepts a
+// single statement, since it runs through a prepared statement.
+func (c *Connection) QueryContext(ctx context.Context, query string, result *Result) error {
+	var stmt PreparedStatement
+	defer stmt.Destroy()
+	if err := c.Prepare(query, &stmt); err != nil {
+		return err
+	}
+	if err := stmt.ExecuteContext(ctx, result); err != nil {
+		if e, ok := err.(*Error); ok {
+			e.Query = query
+		}
+		return err
+	}
+	return nil
+}
+
+// QueryArgs prepares query, binds args with PreparedStatement.Bind and
+// executes it into result.
+func (c *Connection) QueryArgs(query string, result *Result, args ...any) error {
+	var stmt PreparedStatement
+	defer stmt.Destroy()
+	if err := c.Prepare(query, &stmt); err != nil {
+		return err
+	}
+	if err := stmt.Bind(args...); err != nil {
+		return err
+	}
+	if err := stmt.ExecutePrepared(result); err != nil {
+		if e, ok := err.(*Error); ok {
+			e.Query = query
+		}
+		return err
+	}
+	return nil
+}
+
+// Exec is QueryArgs for statements without a result set. It returns the
+// number of rows changed.
+func (c *Connection) Exec(query string, args ...any) (uint64, error) {
+	var result Result
+	defer result.Destroy()
+	if err := c.QueryArgs(query, &result, args...); err != nil {
+		return 0, err
+	}
+	return result.RowsChanged(), nil
+}
+
 func (c *Connection) Prepare(query string, stmt *PreparedStatement) error {
 	cQuery := C.CString(query)
 	defer C.free(unsafe.Pointer(cQuery))
 
 	if C.duckdb_prepare(c.c, cQuery, &stmt.c) == C.DuckDBError {
-		return ErrDuckDBError
+		var msg string
+		if err := stmt.PrepareError(); err != nil {
+			msg = err.Error()
+		}
+		return newError("prepare", query, msg)
 	}
 	return nil
 }
@@ -42,16 +102,16 @@ func (c *Connection) AppenderCreate(schema, table string) (*Appender, error) {
 	defer C.free(unsafe.Pointer(cSchema))
 	cTable := C.CString(table)
 	defer C.free(unsafe.Pointer(cTable))
-	a := &Appender{}
+	a := &Appender{conn: c, schema: schema, table: table}
 	if C.duckdb_appender_create(c.c, cSchema, cTable, &a.c) == C.DuckDBError {
-		return a, ErrDuckDBError
+		return a, newError("appender create", "", a.Error())
 	}
 	return a, nil
 }
 
 func (c *Connection) RegisterTableFunction(function *TableFunction) error {
 	if C.duckdb_register_table_function(c.c, function.c) == C.DuckDBError {
-		return ErrDuckDBError
+		return newError("register table function", "", fmt.Sprintf("cannot register table function %q", function.name))
 	}
 	return nil
 }
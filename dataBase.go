@@ -16,8 +16,10 @@ func Open(path string) (*DataBase, error) {
 	cPath := C.CString(path)
 	defer C.free(unsafe.Pointer(cPath))
 	var db C.duckdb_database
-	if state := C.duckdb_open(cPath, &db); state == C.DuckDBError {
-		return nil, ErrDuckDBError
+	var cError *C.char
+	defer C.duckdb_free(unsafe.Pointer(cError))
+	if state := C.duckdb_open_ext(cPath, &db, nil, &cError); state == C.DuckDBError {
+		return nil, newError("open", "", C.GoString(cError))
 	}
 	return &DataBase{
 		c: db,
@@ -31,7 +33,8 @@ func OpenExt(path string, config *Config) (*DataBase, string, error) {
 	var cError *C.char
 	defer C.duckdb_free(unsafe.Pointer(cError))
 	if state := C.duckdb_open_ext(cPath, &db, config.c, &cError); state == C.DuckDBError {
-		return nil, C.GoString(cError), ErrDuckDBError
+		errStr := C.GoString(cError)
+		return nil, errStr, newError("open", "", errStr)
 	}
 	return &DataBase{
 		c: db,
@@ -45,7 +48,7 @@ func (d *DataBase) Close() {
 func (d *DataBase) Connection() (*Connection, error) {
 	var c C.duckdb_connection
 	if C.duckdb_connect(d.c, &c) == C.DuckDBError {
-		return nil, ErrDuckDBError
+		return nil, newError("connect", "", "")
 	}
 	return &Connection{c}, nil
 }
@@ -1,6 +1,10 @@
 package duckdbcapi
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 var (
 	ErrDuckDBError             = errors.New("ErrDuckDBError")
@@ -13,4 +17,174 @@ var (
 	ErrVectorGetValidityNil    = errors.New("ErrVectorGetValidityNil")
 	ErrVectorGetListChildNil   = errors.New("ErrVectorGetListChildNil")
 	ErrVectorGetStructChildNil = errors.New("ErrVectorGetStructChildNil")
+	ErrVectorTypeMismatch      = errors.New("ErrVectorTypeMismatch")
+	ErrVectorOutOfRange        = errors.New("ErrVectorOutOfRange")
+	ErrVectorListUnsupported   = errors.New("ErrVectorListUnsupported")
+	ErrVarCharLength           = errors.New("ErrVarCharLength")
+)
+
+// ErrorType is the error class DuckDB puts in front of its messages,
+// e.g. "Catalog" for "Catalog Error: Table with name x does not exist!".
+type ErrorType int
+
+const (
+	ErrorTypeUnknown ErrorType = iota
+	ErrorTypeInvalid
+	ErrorTypeOutOfRange
+	ErrorTypeConversion
+	ErrorTypeUnknownType
+	ErrorTypeDecimal
+	ErrorTypeMismatchType
+	ErrorTypeDivideByZero
+	ErrorTypeObjectSize
+	ErrorTypeInvalidType
+	ErrorTypeSerialization
+	ErrorTypeTransaction
+	ErrorTypeNotImplemented
+	ErrorTypeExpression
+	ErrorTypeCatalog
+	ErrorTypeParser
+	ErrorTypePlanner
+	ErrorTypeScheduler
+	ErrorTypeExecutor
+	ErrorTypeConstraint
+	ErrorTypeIndex
+	ErrorTypeStat
+	ErrorTypeConnection
+	ErrorTypeSyntax
+	ErrorTypeSettings
+	ErrorTypeBinder
+	ErrorTypeNetwork
+	ErrorTypeOptimizer
+	ErrorTypeNullPointer
+	ErrorTypeIO
+	ErrorTypeInterrupt
+	ErrorTypeFatal
+	ErrorTypeInternal
+	ErrorTypeInvalidInput
+	ErrorTypeOutOfMemory
+	ErrorTypePermission
+	ErrorTypeParameterNotResolved
+	ErrorTypeParameterNotAllowed
+	ErrorTypeDependency
 )
+
+var errorTypePrefixes = map[string]ErrorType{
+	"Invalid":                ErrorTypeInvalid,
+	"Out of Range":           ErrorTypeOutOfRange,
+	"Conversion":             ErrorTypeConversion,
+	"Unknown Type":           ErrorTypeUnknownType,
+	"Decimal":                ErrorTypeDecimal,
+	"Mismatch Type":          ErrorTypeMismatchType,
+	"Divide by Zero":         ErrorTypeDivideByZero,
+	"Object Size":            ErrorTypeObjectSize,
+	"Invalid type":           ErrorTypeInvalidType,
+	"Serialization":          ErrorTypeSerialization,
+	"TransactionContext":     ErrorTypeTransaction,
+	"Not implemented":        ErrorTypeNotImplemented,
+	"Expression":             ErrorTypeExpression,
+	"Catalog":                ErrorTypeCatalog,
+	"Parser":                 ErrorTypeParser,
+	"Planner":                ErrorTypePlanner,
+	"Scheduler":              ErrorTypeScheduler,
+	"Executor":               ErrorTypeExecutor,
+	"Constraint":             ErrorTypeConstraint,
+	"Index":                  ErrorTypeIndex,
+	"Stat":                   ErrorTypeStat,
+	"Connection":             ErrorTypeConnection,
+	"Syntax":                 ErrorTypeSyntax,
+	"Settings":               ErrorTypeSettings,
+	"Binder":                 ErrorTypeBinder,
+	"Network":                ErrorTypeNetwork,
+	"Optimizer":              ErrorTypeOptimizer,
+	"NullPointer":            ErrorTypeNullPointer,
+	"IO":                     ErrorTypeIO,
+	"INTERRUPT":              ErrorTypeInterrupt,
+	"FATAL":                  ErrorTypeFatal,
+	"INTERNAL":               ErrorTypeInternal,
+	"Invalid Input":          ErrorTypeInvalidInput,
+	"Out of Memory":          ErrorTypeOutOfMemory,
+	"Permission":             ErrorTypePermission,
+	"Parameter Not Resolved": ErrorTypeParameterNotResolved,
+	"Parameter Not Allowed":  ErrorTypeParameterNotAllowed,
+	"Dependency":             ErrorTypeDependency,
+}
+
+func (t ErrorType) String() string {
+	for prefix, errorType := range errorTypePrefixes {
+		if errorType == t {
+			return prefix
+		}
+	}
+	return "Unknown"
+}
+
+// Error is returned by every call that fails inside DuckDB. It carries the
+// operation that failed, the SQL text where known and a message in Msg.
+// Msg is the message DuckDB reported where the C API exposes one. For calls
+// that only report failure, such as binds, config options and table function
+// registration, Msg is generated by this package to describe the call, and
+// for interrupted queries it is the context error. errors.Is(err,
+// ErrDuckDBError) holds for every *Error.
+type Error struct {
+	Type  ErrorType
+	Op    string
+	Query string
+	Msg   string
+	cause error
+}
+
+func newError(op, query, msg string) *Error {
+	return &Error{
+		Type:  parseErrorType(msg),
+		Op:    op,
+		Query: query,
+		Msg:   msg,
+	}
+}
+
+// newContextError reports a query that was interrupted because its context
+// was cancelled or its deadline passed; it unwraps to ctx.Err().
+func newContextError(err error) *Error {
+	return &Error{
+		Type:  ErrorTypeInterrupt,
+		Op:    "interrupt",
+		Msg:   err.Error(),
+		cause: err,
+	}
+}
+
+func parseErrorType(msg string) ErrorType {
+	prefix, _, found := strings.Cut(msg, " Error: ")
+	if !found {
+		return ErrorTypeUnknown
+	}
+	if errorType, ok := errorTypePrefixes[prefix]; ok {
+		return errorType
+	}
+	return ErrorTypeUnknown
+}
+
+func (e *Error) Error() string {
+	if e.Msg == "" {
+		return "duckdb: " + e.Op + " failed"
+	}
+	return "duckdb: " + e.Op + ": " + e.Msg
+}
+
+func (e *Error) Is(target error) bool {
+	return target == ErrDuckDBError
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// checkVarCharLength guards the *VarCharLength functions, which would read
+// past the C copy of v for a length beyond len(v).
+func checkVarCharLength(v string, length uint64) error {
+	if length > uint64(len(v)) {
+		return fmt.Errorf("%w: length %d exceeds %d bytes", ErrVarCharLength, length, len(v))
+	}
+	return nil
+}
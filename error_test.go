@@ -0,0 +1,88 @@
+package duckdbcapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseErrorType(t *testing.T) {
+	assert.Equal(t, ErrorTypeCatalog, parseErrorType("Catalog Error: Table with name x does not exist!"))
+	assert.Equal(t, ErrorTypeParser, parseErrorType("Parser Error: syntax error at or near \"SELEC\""))
+	assert.Equal(t, ErrorTypeConstraint, parseErrorType("Constraint Error: PRIMARY KEY or UNIQUE constraint violated"))
+	assert.Equal(t, ErrorTypeInvalidInput, parseErrorType("Invalid Input Error: Type mismatch"))
+	assert.Equal(t, ErrorTypeTransaction, parseErrorType("TransactionContext Error: cannot commit - no transaction is active"))
+	assert.Equal(t, ErrorTypeUnknown, parseErrorType("something went wrong"))
+	assert.Equal(t, ErrorTypeUnknown, parseErrorType(""))
+	assert.Equal(t, "Catalog", ErrorTypeCatalog.String())
+	assert.Equal(t, "Unknown", ErrorTypeUnknown.String())
+}
+
+func TestStructuredErrorsInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+
+	t.Run("query", func(t *testing.T) {
+		err := tester.NoResultQuery("SELECT * FROM nonexistent")
+		assert.ErrorIs(t, err, ErrDuckDBError)
+		var duckErr *Error
+		assert.Equal(t, true, errors.As(err, &duckErr))
+		assert.Equal(t, ErrorTypeCatalog, duckErr.Type)
+		assert.Equal(t, "query", duckErr.Op)
+		assert.Equal(t, "SELECT * FROM nonexistent", duckErr.Query)
+		assert.NotEqual(t, "", duckErr.Msg)
+
+		err = tester.NoResultQuery("SELEC 42")
+		assert.Equal(t, true, errors.As(err, &duckErr))
+		assert.Equal(t, ErrorTypeParser, duckErr.Type)
+	})
+
+	t.Run("prepare", func(t *testing.T) {
+		var stmt PreparedStatement
+		defer stmt.Destroy()
+		err := tester.conn.Prepare("SELECT * FROM nonexistent WHERE i = $1", &stmt)
+		var duckErr *Error
+		assert.Equal(t, true, errors.As(err, &duckErr))
+		assert.Equal(t, "prepare", duckErr.Op)
+		assert.Equal(t, ErrorTypeCatalog, duckErr.Type)
+		assert.Equal(t, stmt.PrepareError().Error(), duckErr.Msg)
+	})
+
+	t.Run("bind", func(t *testing.T) {
+		var stmt PreparedStatement
+		assert.Nil(t, tester.conn.Prepare("SELECT $1::INTEGER", &stmt))
+		defer stmt.Destroy()
+		err := stmt.BindInt32(2, 1)
+		var duckErr *Error
+		assert.Equal(t, true, errors.As(err, &duckErr))
+		assert.Equal(t, "bind", duckErr.Op)
+		assert.Equal(t, "cannot bind INTEGER to parameter $2", duckErr.Msg)
+	})
+
+	t.Run("execute prepared", func(t *testing.T) {
+		assert.Nil(t, tester.NoResultQuery("CREATE TABLE uniques(i INTEGER PRIMARY KEY)"))
+		var stmt PreparedStatement
+		assert.Nil(t, tester.conn.Prepare("INSERT INTO uniques VALUES (1)", &stmt))
+		defer stmt.Destroy()
+		assert.Nil(t, stmt.ExecutePrepared(nil))
+		err := stmt.ExecutePrepared(nil)
+		var duckErr *Error
+		assert.Equal(t, true, errors.As(err, &duckErr))
+		assert.Equal(t, ErrorTypeConstraint, duckErr.Type)
+	})
+
+	t.Run("appender", func(t *testing.T) {
+		assert.Nil(t, tester.NoResultQuery("CREATE TABLE appended(i INTEGER)"))
+		appender, err := tester.conn.AppenderCreate("", "appended")
+		assert.Nil(t, err)
+		defer appender.Destroy()
+		assert.Nil(t, appender.BeginRow())
+		err = appender.EndRow()
+		var duckErr *Error
+		assert.Equal(t, true, errors.As(err, &duckErr))
+		assert.Equal(t, "end row", duckErr.Op)
+		assert.Equal(t, appender.Error(), duckErr.Msg)
+	})
+}
@@ -0,0 +1,270 @@
+package duckdbcapi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrIngestorClosed = errors.New("ErrIngestorClosed")
+
+type IngestorOptions struct {
+	// QueueSize is the number of pending requests per table before Append
+	// and AppendColumns block. It defaults to VectorSize.
+	QueueSize int
+	// FlushInterval flushes every table periodically when set.
+	FlushInterval time.Duration
+}
+
+// Ingestor funnels rows from many goroutines into DuckDB. Every target table
+// gets its own Connection and Appender, owned by a goroutine that drains a
+// bounded queue, so producers block once the queue is full. Rows are staged
+// in DataChunks by Appender.AppendRow.
+//
+// Appending is asynchronous: errors of queued rows are reported by the next
+// Flush, which acknowledges that every row queued before it is stored. When
+// DuckDB rejects a flush, e.g. for a constraint violation, the rows of that
+// flush are lost and the table continues with a new Appender.
+type Ingestor struct {
+	db     *DataBase
+	opts   IngestorOptions
+	mu     sync.Mutex
+	tables map[[2]string]*IngestTable
+	closed bool
+}
+
+func NewIngestor(db *DataBase, opts IngestorOptions) *Ingestor {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = int(VectorSize())
+	}
+	return &Ingestor{db: db, opts: opts, tables: map[[2]string]*IngestTable{}}
+}
+
+// IngestTable queues rows for one table of an Ingestor.
+type IngestTable struct {
+	conn          *Connection
+	schema, table string
+	appender      *Appender
+	queue         chan ingestRequest
+	// mu is held for reading while a request is queued, so no request is
+	// queued after the close request
+	mu     sync.RWMutex
+	closed bool
+	// err is the first error since the last flush, owned by the worker
+	err error
+}
+
+type ingestKind int
+
+const (
+	ingestRow ingestKind = iota
+	ingestColumns
+	ingestFlush
+	ingestClose
+)
+
+type ingestRequest struct {
+	kind   ingestKind
+	values []any
+	ack    chan error
+}
+
+// Table returns the queue for schema.table, creating its Connection and
+// Appender on first use.
+func (in *Ingestor) Table(schema, table string) (*IngestTable, error) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if in.closed {
+		return nil, ErrIngestorClosed
+	}
+	key := [2]string{schema, table}
+	if t, ok := in.tables[key]; ok {
+		return t, nil
+	}
+	conn, err := in.db.Connection()
+	if err != nil {
+		return nil, err
+	}
+	appender, err := conn.AppenderCreate(schema, table)
+	if err != nil {
+		appender.Destroy()
+		conn.Disconnect()
+		return nil, err
+	}
+	t := &IngestTable{
+		conn:     conn,
+		schema:   schema,
+		table:    table,
+		appender: appender,
+		queue:    make(chan ingestRequest, in.opts.QueueSize),
+	}
+	go t.run(in.opts.FlushInterval)
+	in.tables[key] = t
+	return t, nil
+}
+
+func (t *IngestTable) run(interval time.Duration) {
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case <-tick:
+			t.record(t.appender.Flush())
+		case req := <-t.queue:
+			switch req.kind {
+			case ingestRow:
+				t.record(t.appender.AppendRow(req.values...))
+			case ingestColumns:
+				t.record(t.appender.AppendColumns(req.values...))
+			case ingestFlush:
+				t.record(t.appender.Flush())
+				req.ack <- t.err
+				t.err = nil
+			case ingestClose:
+				if err := t.appender.Destroy(); err != nil && t.err == nil {
+					t.err = err
+				}
+				t.conn.Disconnect()
+				req.ack <- t.err
+				return
+			}
+		}
+	}
+}
+
+// record keeps the first error since the last flush. A DuckDB error leaves
+// the rows that failed in the appender, so every later flush would fail too;
+// the appender is replaced and those rows are dropped.
+func (t *IngestTable) record(err error) {
+	if err == nil {
+		return
+	}
+	if t.err == nil {
+		t.err = err
+	}
+	if !errors.Is(err, ErrDuckDBError) {
+		return
+	}
+	t.appender.Destroy()
+	appender, err := t.conn.AppenderCreate(t.schema, t.table)
+	if err != nil && t.err == nil {
+		t.err = err
+	}
+	t.appender = appender
+}
+
+func (t *IngestTable) send(ctx context.Context, req ingestRequest) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.closed {
+		return ErrIngestorClosed
+	}
+	select {
+	case t.queue <- req:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Append queues a row for Appender.AppendRow, blocking while the queue is
+// full. The values must not be modified afterwards.
+func (t *IngestTable) Append(ctx context.Context, values ...any) error {
+	return t.send(ctx, ingestRequest{kind: ingestRow, values: append([]any(nil), values...)})
+}
+
+// AppendColumns queues a columnar batch for Appender.AppendColumns. The
+// slices must not be modified afterwards.
+func (t *IngestTable) AppendColumns(ctx context.Context, cols ...any) error {
+	return t.send(ctx, ingestRequest{kind: ingestColumns, values: append([]any(nil), cols...)})
+}
+
+// Flush waits until every row queued before it is flushed and returns the
+// first error since the previous Flush.
+func (t *IngestTable) Flush(ctx context.Context) error {
+	ack := make(chan error, 1)
+	if err := t.send(ctx, ingestRequest{kind: ingestFlush, ack: ack}); err != nil {
+		return err
+	}
+	return wait(ctx, ack)
+}
+
+// close queues the close request behind the pending rows and waits for it.
+func (t *IngestTable) close(ctx context.Context) error {
+	t.mu.Lock()
+	closed := t.closed
+	t.closed = true
+	t.mu.Unlock()
+	if closed {
+		return ErrIngestorClosed
+	}
+	ack := make(chan error, 1)
+	go func() { t.queue <- ingestRequest{kind: ingestClose, ack: ack} }()
+	return wait(ctx, ack)
+}
+
+func wait(ctx context.Context, ack <-chan error) error {
+	select {
+	case err := <-ack:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func each(tables []*IngestTable, do func(*IngestTable) error) error {
+	errs := make([]error, len(tables))
+	var wg sync.WaitGroup
+	for i, t := range tables {
+		wg.Add(1)
+		go func(i int, t *IngestTable) {
+			defer wg.Done()
+			errs[i] = do(t)
+		}(i, t)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (in *Ingestor) snapshot() []*IngestTable {
+	tables := make([]*IngestTable, 0, len(in.tables))
+	for _, t := range in.tables {
+		tables = append(tables, t)
+	}
+	return tables
+}
+
+// Flush flushes every table, see IngestTable.Flush.
+func (in *Ingestor) Flush(ctx context.Context) error {
+	in.mu.Lock()
+	tables := in.snapshot()
+	in.mu.Unlock()
+	return each(tables, func(t *IngestTable) error { return t.Flush(ctx) })
+}
+
+// Close stops accepting rows, stores the queued rows and releases the
+// Connections and Appenders. If ctx ends first Close returns ctx.Err() while
+// the table goroutines still own their Connections and Appenders; they close
+// them in the background once the queued rows are stored, and the DataBase
+// must stay open until then.
+func (in *Ingestor) Close(ctx context.Context) error {
+	in.mu.Lock()
+	if in.closed {
+		in.mu.Unlock()
+		return ErrIngestorClosed
+	}
+	in.closed = true
+	tables := in.snapshot()
+	in.mu.Unlock()
+	return each(tables, func(t *IngestTable) error { return t.close(ctx) })
+}
@@ -0,0 +1,104 @@
+package duckdbcapi
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIngestorInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+	assert.Nil(t, tester.NoResultQuery("CREATE TABLE events (id INTEGER, name VARCHAR)"))
+	assert.Nil(t, tester.NoResultQuery("CREATE TABLE metrics (value DOUBLE)"))
+	ctx := context.Background()
+
+	in := NewIngestor(tester.db, IngestorOptions{QueueSize: 8})
+	events, err := in.Table("", "events")
+	assert.Nil(t, err)
+	again, err := in.Table("", "events")
+	assert.Nil(t, err)
+	assert.Same(t, events, again)
+	metrics, err := in.Table("", "metrics")
+	assert.Nil(t, err)
+	_, err = in.Table("", "missing")
+	assert.NotNil(t, err)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				assert.Nil(t, events.Append(ctx, g*100+i, "event"))
+			}
+			assert.Nil(t, metrics.AppendColumns(ctx, []float64{1, 2, 3}))
+		}(g)
+	}
+	wg.Wait()
+	assert.Nil(t, in.Flush(ctx))
+	assert.Equal(t, int64(1600), countRows(t, tester.conn, "events"))
+	assert.Equal(t, int64(48), countRows(t, tester.conn, "metrics"))
+
+	// conversion errors are acknowledged by the next Flush
+	assert.Nil(t, events.Append(ctx, "not a number", "bad"))
+	assert.NotNil(t, events.Flush(ctx))
+	assert.Nil(t, events.Flush(ctx))
+
+	// Close stores the rows still queued
+	for i := 0; i < 8; i++ {
+		assert.Nil(t, events.Append(ctx, i, "queued"))
+	}
+	assert.Nil(t, in.Close(ctx))
+	assert.Equal(t, int64(1608), countRows(t, tester.conn, "events"))
+	assert.ErrorIs(t, events.Append(ctx, 1, "closed"), ErrIngestorClosed)
+	assert.ErrorIs(t, in.Flush(ctx), ErrIngestorClosed)
+	assert.ErrorIs(t, in.Close(ctx), ErrIngestorClosed)
+	_, err = in.Table("", "events")
+	assert.ErrorIs(t, err, ErrIngestorClosed)
+}
+
+func TestIngestorFlushIntervalInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+	assert.Nil(t, tester.NoResultQuery("CREATE TABLE events (id INTEGER)"))
+	ctx := context.Background()
+
+	in := NewIngestor(tester.db, IngestorOptions{FlushInterval: 10 * time.Millisecond})
+	defer in.Close(ctx)
+	events, err := in.Table("", "events")
+	assert.Nil(t, err)
+	assert.Nil(t, events.Append(ctx, 1))
+	assert.Eventually(t, func() bool {
+		return countRows(t, tester.conn, "events") == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestIngestorConstraintErrorInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+	assert.Nil(t, tester.NoResultQuery("CREATE TABLE keys (id INTEGER PRIMARY KEY)"))
+	ctx := context.Background()
+
+	in := NewIngestor(tester.db, IngestorOptions{})
+	keys, err := in.Table("", "keys")
+	assert.Nil(t, err)
+	assert.Nil(t, keys.Append(ctx, 1))
+	assert.Nil(t, keys.Append(ctx, 1))
+	err = keys.Flush(ctx)
+	assert.ErrorIs(t, err, ErrDuckDBError)
+	assert.Equal(t, int64(0), countRows(t, tester.conn, "keys"))
+
+	// the failed rows are dropped and later rows are stored
+	assert.Nil(t, keys.Append(ctx, 1))
+	assert.Nil(t, keys.Append(ctx, 2))
+	assert.Nil(t, keys.Flush(ctx))
+	assert.Nil(t, in.Close(ctx))
+	assert.Equal(t, int64(2), countRows(t, tester.conn, "keys"))
+}
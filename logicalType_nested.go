@@ -0,0 +1,86 @@
+package duckdbcapi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrLogicalTypeInvalid     = errors.New("ErrLogicalTypeInvalid")
+	ErrLogicalTypeUnsupported = errors.New("ErrLogicalTypeUnsupported")
+)
+
+func enumSQL(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	}
+	return "ENUM(" + strings.Join(quoted, ",") + ")"
+}
+
+// logicalTypeSQL renders lt as a SQL type. ENUM types are rendered by enum,
+// since they can only be named inside the database that defines them.
+func logicalTypeSQL(lt *LogicalType, enum func(*LogicalType) (string, error)) (string, error) {
+	switch typ := lt.GetTypeId(); typ {
+	case DuckDBTypeDecimal:
+		return fmt.Sprintf("DECIMAL(%d,%d)", lt.DecimalWidth(), lt.DecimalScale()), nil
+	case DuckDBTypeEnum:
+		return enum(lt)
+	case DuckDBTypeList:
+		child := lt.ListTypeChildType()
+		defer child.Destroy()
+		sql, err := logicalTypeSQL(child, enum)
+		if err != nil {
+			return "", err
+		}
+		return sql + "[]", nil
+	case DuckDBTypeStruct:
+		fields := make([]string, lt.StructTypeChildCount())
+		for i := range fields {
+			child := lt.StructTypeChildType(uint64(i))
+			sql, err := logicalTypeSQL(child, enum)
+			child.Destroy()
+			if err != nil {
+				return "", err
+			}
+			fields[i] = quoteIdentifier(lt.StructTypeChildName(uint64(i))) + " " + sql
+		}
+		return "STRUCT(" + strings.Join(fields, ", ") + ")", nil
+	case DuckDBTypeMap:
+		// a MAP is stored as STRUCT(key K[], value V[])
+		var kv [2]string
+		for i := range kv {
+			list := lt.StructTypeChildType(uint64(i))
+			child := list.ListTypeChildType()
+			sql, err := logicalTypeSQL(child, enum)
+			child.Destroy()
+			list.Destroy()
+			if err != nil {
+				return "", err
+			}
+			kv[i] = sql
+		}
+		return "MAP(" + kv[0] + ", " + kv[1] + ")", nil
+	case DuckDBTypeInvalid:
+		return "", fmt.Errorf("%w: INVALID", ErrLogicalTypeInvalid)
+	default:
+		return typeName(typ), nil
+	}
+}
+
+// unsupportedType reports a type the v0.3.4 C API cannot construct: there is
+// no duckdb_create_list_type, duckdb_create_struct_type,
+// duckdb_create_map_type or duckdb_create_enum_type. Such types can only be
+// obtained from DuckDB, e.g. with Result.ColumnLogicalType.
+func unsupportedType(typ Type) error {
+	return fmt.Errorf("%w: %s types cannot be created with the v0.3.4 C API", ErrLogicalTypeUnsupported, typeName(typ))
+}
+
+func CreateUUIDType() *LogicalType {
+	return CreateLogicalType(DuckDBTypeUUID)
+}
+
+func CreateJSONType() *LogicalType {
+	return CreateLogicalType(DuckDBTypeJson)
+}
@@ -0,0 +1,48 @@
+package duckdbcapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNestedLogicalTypesInCAPI(t *testing.T) {
+	uuid := CreateUUIDType()
+	defer uuid.Destroy()
+	assert.Equal(t, DuckDBTypeUUID, uuid.GetTypeId())
+	json := CreateJSONType()
+	defer json.Destroy()
+	assert.Equal(t, DuckDBTypeJson, json.GetTypeId())
+
+	// nested types come from DuckDB itself
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+	var result Result
+	assert.Nil(t, tester.conn.Query(`SELECT NULL::STRUCT(id INTEGER, tags VARCHAR[]) AS st, NULL::INTEGER[] AS l,
+		NULL::MAP(VARCHAR, INTEGER) AS m`, &result))
+	defer result.Destroy()
+	types := make([]*LogicalType, result.ColumnCount())
+	for i := range types {
+		types[i] = result.ColumnLogicalType(uint64(i))
+		defer types[i].Destroy()
+	}
+	assert.Equal(t, DuckDBTypeStruct, types[0].GetTypeId())
+	assert.Equal(t, "tags", types[0].StructTypeChildName(1))
+
+	for _, lt := range types {
+		_, err := lt.Clone()
+		assert.ErrorIs(t, err, ErrLogicalTypeUnsupported)
+	}
+
+	chunk, err := CreateDataChunk(types, uint64(len(types)))
+	assert.Nil(t, err)
+	defer chunk.Destroy()
+	vec, err := chunk.GetVector(0)
+	assert.Nil(t, err)
+	vecType := vec.GetColumnType()
+	assert.Equal(t, DuckDBTypeStruct, vecType.GetTypeId())
+	vecType.Destroy()
+	_, err = vec.StructGetChild(1)
+	assert.Nil(t, err)
+}
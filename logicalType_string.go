@@ -0,0 +1,298 @@
+package duckdbcapi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrLogicalTypeSyntax = errors.New("ErrLogicalTypeSyntax")
+
+// String renders l in DuckDB SQL type syntax, e.g. "DECIMAL(18,3)",
+// "STRUCT(a INTEGER, b VARCHAR[])" or "ENUM('x','y')".
+func (l *LogicalType) String() string {
+	sql, err := logicalTypeSQL(l, func(lt *LogicalType) (string, error) {
+		values := make([]string, lt.EnumDictionarySize())
+		for i := range values {
+			values[i] = lt.EnumDictionaryValue(uint64(i))
+		}
+		return enumSQL(values), nil
+	})
+	if err != nil {
+		return "INVALID"
+	}
+	return sql
+}
+
+// Equal reports whether l and other are the same type, including DECIMAL
+// width and scale, ENUM dictionaries and the names and types of nested
+// children.
+func (l *LogicalType) Equal(other *LogicalType) bool {
+	typ := l.GetTypeId()
+	if typ != other.GetTypeId() {
+		return false
+	}
+	switch typ {
+	case DuckDBTypeDecimal:
+		return l.DecimalWidth() == other.DecimalWidth() && l.DecimalScale() == other.DecimalScale()
+	case DuckDBTypeEnum:
+		size := l.EnumDictionarySize()
+		if size != other.EnumDictionarySize() {
+			return false
+		}
+		for i := uint64(0); i < uint64(size); i++ {
+			if l.EnumDictionaryValue(i) != other.EnumDictionaryValue(i) {
+				return false
+			}
+		}
+	case DuckDBTypeList:
+		child, otherChild := l.ListTypeChildType(), other.ListTypeChildType()
+		defer child.Destroy()
+		defer otherChild.Destroy()
+		return child.Equal(otherChild)
+	case DuckDBTypeStruct, DuckDBTypeMap:
+		count := l.StructTypeChildCount()
+		if count != other.StructTypeChildCount() {
+			return false
+		}
+		for i := uint64(0); i < count; i++ {
+			if l.StructTypeChildName(i) != other.StructTypeChildName(i) {
+				return false
+			}
+			child, otherChild := l.StructTypeChildType(i), other.StructTypeChildType(i)
+			equal := child.Equal(otherChild)
+			child.Destroy()
+			otherChild.Destroy()
+			if !equal {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Clone returns an independent copy of l that must be destroyed separately.
+// LIST, STRUCT, MAP and ENUM types cannot be created with the v0.3.4 C API,
+// so cloning them returns ErrLogicalTypeUnsupported.
+func (l *LogicalType) Clone() (*LogicalType, error) {
+	switch typ := l.GetTypeId(); typ {
+	case DuckDBTypeDecimal:
+		return CreateDecimalType(l.DecimalWidth(), l.DecimalScale()), nil
+	case DuckDBTypeEnum, DuckDBTypeList, DuckDBTypeStruct, DuckDBTypeMap:
+		return nil, unsupportedType(typ)
+	default:
+		return CreateLogicalType(typ), nil
+	}
+}
+
+var typeAliases = map[string]Type{
+	"BOOLEAN": DuckDBTypeBoolean, "BOOL": DuckDBTypeBoolean, "LOGICAL": DuckDBTypeBoolean,
+	"TINYINT": DuckDBTypeTinyInt, "INT1": DuckDBTypeTinyInt,
+	"SMALLINT": DuckDBTypeSmallInt, "INT2": DuckDBTypeSmallInt, "SHORT": DuckDBTypeSmallInt,
+	"INTEGER": DuckDBTypeInteger, "INT": DuckDBTypeInteger, "INT4": DuckDBTypeInteger, "SIGNED": DuckDBTypeInteger,
+	"BIGINT": DuckDBTypeBigInt, "INT8": DuckDBTypeBigInt, "LONG": DuckDBTypeBigInt,
+	"UTINYINT": DuckDBTypeUTinyInt, "USMALLINT": DuckDBTypeUSmallInt,
+	"UINTEGER": DuckDBTypeUInteger, "UBIGINT": DuckDBTypeUBigInt,
+	"HUGEINT": DuckDBTypeHugeInt, "INT128": DuckDBTypeHugeInt,
+	"FLOAT": DuckDBTypeFloat, "REAL": DuckDBTypeFloat, "FLOAT4": DuckDBTypeFloat,
+	"DOUBLE": DuckDBTypeDouble, "FLOAT8": DuckDBTypeDouble,
+	"VARCHAR": DuckDBTypeVarChar, "STRING": DuckDBTypeVarChar, "TEXT": DuckDBTypeVarChar,
+	"CHAR": DuckDBTypeVarChar, "BPCHAR": DuckDBTypeVarChar,
+	"BLOB": DuckDBTypeBlob, "BYTEA": DuckDBTypeBlob, "BINARY": DuckDBTypeBlob, "VARBINARY": DuckDBTypeBlob,
+	"DATE": DuckDBTypeDate, "TIME": DuckDBTypeTime, "INTERVAL": DuckDBTypeInterval,
+	"TIMESTAMP": DuckDBTypeTimestamp, "DATETIME": DuckDBTypeTimestamp,
+	"TIMESTAMP_S": DuckDBTypeTimestamp_S, "TIMESTAMP_MS": DuckDBTypeTimestamp_MS,
+	"TIMESTAMP_NS": DuckDBTypeTimestamp_NS, "UUID": DuckDBTypeUUID, "JSON": DuckDBTypeJson,
+}
+
+// ParseLogicalType builds the type described by DuckDB SQL type syntax such
+// as "DECIMAL(18,3)". Common aliases like INT or TEXT are accepted. LIST,
+// STRUCT, MAP and ENUM types return ErrLogicalTypeUnsupported, since the
+// v0.3.4 C API cannot create them.
+func ParseLogicalType(sql string) (*LogicalType, error) {
+	p := &typeParser{tokens: tokenizeType(sql)}
+	lt, err := p.parseType()
+	if errors.Is(err, ErrLogicalTypeUnsupported) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %v", ErrLogicalTypeSyntax, sql, err)
+	}
+	if tok := p.peek(); tok != "" {
+		lt.Destroy()
+		return nil, fmt.Errorf("%w: %q: unexpected %q", ErrLogicalTypeSyntax, sql, tok)
+	}
+	return lt, nil
+}
+
+// tokenizeType splits a type into words, numbers, quoted identifiers (kept
+// with their double quotes), string literals (kept with their single
+// quotes) and single punctuation characters.
+func tokenizeType(sql string) []string {
+	var tokens []string
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"' || c == '\'':
+			j := i + 1
+			for j < len(sql) {
+				if sql[j] == c {
+					if j+1 < len(sql) && sql[j+1] == c {
+						j += 2
+						continue
+					}
+					break
+				}
+				j++
+			}
+			if j < len(sql) {
+				j++
+			}
+			tokens = append(tokens, sql[i:j])
+			i = j
+		case isIdentifierByte(c):
+			j := i
+			for j < len(sql) && isIdentifierByte(sql[j]) {
+				j++
+			}
+			tokens = append(tokens, sql[i:j])
+			i = j
+		default:
+			tokens = append(tokens, sql[i:i+1])
+			i++
+		}
+	}
+	return tokens
+}
+
+func isIdentifierByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+type typeParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *typeParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *typeParser) next() string {
+	tok := p.peek()
+	p.pos++
+	return tok
+}
+
+func (p *typeParser) expect(tok string) error {
+	if got := p.next(); got != tok {
+		return fmt.Errorf("expected %q, got %q", tok, got)
+	}
+	return nil
+}
+
+func (p *typeParser) parseType() (*LogicalType, error) {
+	lt, err := p.parseBaseType()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() == "[" {
+		lt.Destroy()
+		return nil, unsupportedType(DuckDBTypeList)
+	}
+	return lt, nil
+}
+
+var nestedTypes = map[string]Type{
+	"LIST": DuckDBTypeList, "STRUCT": DuckDBTypeStruct, "ROW": DuckDBTypeStruct,
+	"MAP": DuckDBTypeMap, "ENUM": DuckDBTypeEnum,
+}
+
+func (p *typeParser) parseBaseType() (*LogicalType, error) {
+	name := strings.ToUpper(p.next())
+	if name == "DECIMAL" || name == "NUMERIC" {
+		return p.parseDecimal()
+	}
+	if typ, ok := nestedTypes[name]; ok {
+		return nil, unsupportedType(typ)
+	}
+	typ, ok := typeAliases[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %q", name)
+	}
+	if name == "VARCHAR" || name == "CHAR" || name == "BPCHAR" || name == "STRING" || name == "TEXT" {
+		// the length modifier is accepted but not enforced by DuckDB
+		if p.peek() == "(" {
+			p.next()
+			if _, err := strconv.Atoi(p.next()); err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return CreateLogicalType(typ), nil
+}
+
+func (p *typeParser) parseDecimal() (*LogicalType, error) {
+	width, scale := 18, 3
+	if p.peek() == "(" {
+		p.next()
+		var err error
+		if width, err = strconv.Atoi(p.next()); err != nil {
+			return nil, err
+		}
+		scale = 0
+		if p.peek() == "," {
+			p.next()
+			if scale, err = strconv.Atoi(p.next()); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+	}
+	if width < 1 || width > 38 || scale < 0 || scale > width {
+		return nil, fmt.Errorf("invalid DECIMAL(%d,%d)", width, scale)
+	}
+	return CreateDecimalType(uint8(width), uint8(scale)), nil
+}
+
+// reservedKeywords are the DuckDB keywords that cannot be used as a bare
+// STRUCT field name.
+var reservedKeywords = map[string]bool{
+	"all": true, "analyse": true, "analyze": true, "and": true, "any": true, "array": true, "as": true,
+	"asc": true, "asymmetric": true, "both": true, "case": true, "cast": true, "check": true,
+	"collate": true, "column": true, "constraint": true, "create": true, "default": true,
+	"deferrable": true, "desc": true, "describe": true, "distinct": true, "do": true, "else": true,
+	"end": true, "except": true, "false": true, "fetch": true, "for": true, "foreign": true,
+	"from": true, "grant": true, "group": true, "having": true, "in": true, "initially": true,
+	"intersect": true, "into": true, "lateral": true, "leading": true, "limit": true, "offset": true,
+	"on": true, "only": true, "or": true, "order": true, "placing": true, "primary": true,
+	"references": true, "returning": true, "select": true, "show": true, "some": true,
+	"summarize": true, "symmetric": true, "table": true, "then": true, "to": true, "trailing": true,
+	"true": true, "union": true, "unique": true, "using": true, "variadic": true, "when": true,
+	"where": true, "window": true, "with": true,
+}
+
+// quoteIdentifier returns name as is when it is a plain lower case
+// identifier and double quoted otherwise.
+func quoteIdentifier(name string) string {
+	plain := name != "" && !reservedKeywords[name] && (name[0] < '0' || name[0] > '9')
+	for i := 0; plain && i < len(name); i++ {
+		c := name[i]
+		plain = c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z'
+	}
+	if plain {
+		return name
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
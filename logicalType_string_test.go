@@ -0,0 +1,75 @@
+package duckdbcapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogicalTypeStringInCAPI(t *testing.T) {
+	for sql, want := range map[string]string{
+		"DECIMAL(18,3)": "DECIMAL(18,3)",
+		"numeric(4)":    "DECIMAL(4,0)",
+		"int":           "INTEGER",
+		"VARCHAR(10)":   "VARCHAR",
+		"timestamp_ms":  "TIMESTAMP_MS",
+	} {
+		lt, err := ParseLogicalType(sql)
+		if !assert.Nil(t, err, sql) {
+			continue
+		}
+		assert.Equal(t, want, lt.String(), sql)
+
+		clone, err := lt.Clone()
+		assert.Nil(t, err)
+		assert.Equal(t, true, lt.Equal(clone), sql)
+		assert.Equal(t, want, clone.String())
+		clone.Destroy()
+		lt.Destroy()
+	}
+
+	for _, sql := range []string{"", "NOPE", "DECIMAL(40,2)", "VARCHAR(x)", "INTEGER BIGINT"} {
+		_, err := ParseLogicalType(sql)
+		assert.ErrorIs(t, err, ErrLogicalTypeSyntax, sql)
+	}
+	for _, sql := range []string{"INTEGER[]", "LIST(INTEGER)", "STRUCT(a INTEGER)", "MAP(VARCHAR, INTEGER)", "ENUM('x')"} {
+		_, err := ParseLogicalType(sql)
+		assert.ErrorIs(t, err, ErrLogicalTypeUnsupported, sql)
+	}
+
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+	var result Result
+	assert.Nil(t, tester.conn.Query("SELECT {'a': 1, 'b': ['x']} AS s, 1.5::DECIMAL(4,1) AS d", &result))
+	defer result.Destroy()
+	col := result.ColumnLogicalType(0)
+	defer col.Destroy()
+	assert.Equal(t, "STRUCT(a INTEGER, b VARCHAR[])", col.String())
+	d := result.ColumnLogicalType(1)
+	defer d.Destroy()
+	assert.Equal(t, "DECIMAL(4,1)", d.String())
+	expected, err := ParseLogicalType("DECIMAL(4,1)")
+	assert.Nil(t, err)
+	defer expected.Destroy()
+	assert.Equal(t, true, d.Equal(expected))
+
+	// nested types are compared structurally
+	var nested Result
+	assert.Nil(t, tester.NoResultQuery("CREATE TYPE ab AS ENUM ('a', 'b'); CREATE TYPE ba AS ENUM ('b', 'a')"))
+	assert.Nil(t, tester.conn.Query(`SELECT NULL::STRUCT(a INTEGER), NULL::STRUCT(b INTEGER), NULL::STRUCT(a BIGINT),
+		NULL::INTEGER[], NULL::BIGINT[], NULL::MAP(VARCHAR, INTEGER), NULL::MAP(VARCHAR, BIGINT),
+		NULL::ab, NULL::ba, NULL::STRUCT(a INTEGER), NULL::ab`, &nested))
+	defer nested.Destroy()
+	types := make([]*LogicalType, nested.ColumnCount())
+	for i := range types {
+		types[i] = nested.ColumnLogicalType(uint64(i))
+		defer types[i].Destroy()
+	}
+	for _, pair := range [][2]int{{0, 1}, {0, 2}, {3, 4}, {5, 6}, {7, 8}} {
+		assert.Equal(t, false, types[pair[0]].Equal(types[pair[1]]), types[pair[0]].String())
+	}
+	assert.Equal(t, true, types[0].Equal(types[9]))
+	assert.Equal(t, true, types[7].Equal(types[10]))
+	assert.Equal(t, "ENUM('a','b')", types[7].String())
+}
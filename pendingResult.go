@@ -0,0 +1,79 @@
+package duckdbcapi
+
+/*
+#include <duckdb.h>
+*/
+import "C"
+import (
+	"context"
+	"runtime"
+)
+
+type PendingState C.duckdb_pending_state
+
+const (
+	PendingResultReady    PendingState = C.DUCKDB_PENDING_RESULT_READY
+	PendingResultNotReady PendingState = C.DUCKDB_PENDING_RESULT_NOT_READY
+	PendingError          PendingState = C.DUCKDB_PENDING_ERROR
+)
+
+// PendingResult is a query that has been started but not executed yet.
+// Call ExecuteTask until it reports PendingResultReady, then Execute to
+// obtain the Result. It must be destroyed even if PendingPrepared fails.
+type PendingResult struct {
+	c C.duckdb_pending_result
+}
+
+func (p *PreparedStatement) PendingPrepared(pending *PendingResult) error {
+	if C.duckdb_pending_prepared(p.c, &pending.c) == C.DuckDBError {
+		return newError("pending prepared", "", pending.Error())
+	}
+	return nil
+}
+
+func (p *PendingResult) Destroy() {
+	C.duckdb_destroy_pending(&p.c)
+}
+
+func (p *PendingResult) Error() string {
+	err := C.duckdb_pending_error(p.c)
+	if err == nil {
+		return ""
+	}
+	return C.GoString(err)
+}
+
+func (p *PendingResult) ExecuteTask() PendingState {
+	return PendingState(C.duckdb_pending_execute_task(p.c))
+}
+
+func (p *PendingResult) Execute(result *Result) error {
+	if result == nil {
+		var r Result
+		defer r.Destroy()
+		result = &r
+	}
+	if C.duckdb_execute_pending(p.c, &result.c) == C.DuckDBError {
+		return newError("execute pending", "", result.ResultError())
+	}
+	return nil
+}
+
+// executeContext runs the pending query task by task and gives up once ctx
+// is done. ctx is only checked between tasks, so cancellation waits for the
+// running task to finish. Destroying the pending result afterwards aborts the
+// query and leaves the connection usable.
+func (p *PendingResult) executeContext(ctx context.Context, result *Result) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return newContextError(err)
+		}
+		switch p.ExecuteTask() {
+		case PendingError:
+			return newError("execute task", "", p.Error())
+		case PendingResultReady:
+			return p.Execute(result)
+		}
+		runtime.Gosched()
+	}
+}
@@ -0,0 +1,156 @@
+package duckdbcapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryContextInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+
+	t.Run("completes", func(t *testing.T) {
+		var result Result
+		defer result.Destroy()
+		assert.Nil(t, tester.conn.QueryContext(context.Background(), "SELECT SUM(i) FROM range(1000) tbl(i)", &result))
+		assert.Equal(t, int64(499500), result.ValueInt64(0, 0))
+	})
+
+	t.Run("already cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := tester.conn.QueryContext(ctx, "SELECT 42", nil)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorIs(t, err, ErrDuckDBError)
+	})
+
+	t.Run("deadline interrupts running query", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		var result Result
+		defer result.Destroy()
+		err := tester.conn.QueryContext(ctx, "SELECT COUNT(*) FROM range(100000000000) t1", &result)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, true, time.Since(start) < 10*time.Second)
+
+		// the connection stays usable after the interrupt
+		var result2 Result
+		defer result2.Destroy()
+		assert.Nil(t, tester.conn.Query("SELECT 42", &result2))
+		assert.Equal(t, int64(42), result2.ValueInt64(0, 0))
+	})
+
+	t.Run("cancel interrupts running query", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			cancel()
+		}()
+		err := tester.conn.QueryContext(ctx, "SELECT COUNT(*) FROM range(100000000000) t1", nil)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		var result Result
+		defer result.Destroy()
+		assert.Nil(t, tester.conn.Query("SELECT COUNT(*) FROM range(10) t1", &result))
+		assert.Equal(t, int64(10), result.ValueInt64(0, 0))
+	})
+
+	t.Run("prepared statement", func(t *testing.T) {
+		var stmt PreparedStatement
+		assert.Nil(t, tester.conn.Prepare("SELECT COUNT(*) FROM range($1) t1", &stmt))
+		defer stmt.Destroy()
+
+		assert.Nil(t, stmt.BindInt64(1, 10))
+		var result Result
+		assert.Nil(t, stmt.ExecuteContext(context.Background(), &result))
+		assert.Equal(t, int64(10), result.ValueInt64(0, 0))
+		result.Destroy()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		assert.Nil(t, stmt.BindInt64(1, 100000000000))
+		assert.ErrorIs(t, stmt.ExecuteContext(ctx, nil), context.DeadlineExceeded)
+
+		// and the statement can run again
+		assert.Nil(t, stmt.BindInt64(1, 5))
+		assert.Nil(t, stmt.ExecuteContext(context.Background(), &result))
+		assert.Equal(t, int64(5), result.ValueInt64(0, 0))
+		result.Destroy()
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		err := tester.conn.QueryContext(context.Background(), "SELECT * FROM nonexistent", nil)
+		assert.ErrorIs(t, err, ErrDuckDBError)
+		e, ok := err.(*Error)
+		assert.Equal(t, true, ok)
+		assert.Equal(t, ErrorTypeCatalog, e.Type)
+	})
+}
+
+func TestPendingResultInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+
+	var stmt PreparedStatement
+	assert.Nil(t, tester.conn.Prepare("SELECT SUM(i) FROM range($1) tbl(i)", &stmt))
+	defer stmt.Destroy()
+	assert.Nil(t, stmt.BindInt64(1, 1000000))
+
+	var pending PendingResult
+	assert.Nil(t, stmt.PendingPrepared(&pending))
+	tasks := 0
+	for {
+		state := pending.ExecuteTask()
+		assert.NotEqual(t, PendingError, state)
+		tasks++
+		if state == PendingResultReady {
+			break
+		}
+	}
+	assert.Equal(t, true, tasks > 0)
+	assert.Equal(t, "", pending.Error())
+	var result Result
+	assert.Nil(t, pending.Execute(&result))
+	assert.Equal(t, Double(499999500000), HugeIntToDouble(result.ValueHugeInt(0, 0)))
+	result.Destroy()
+	pending.Destroy()
+	pending.Destroy()
+
+	// execution errors surface through the pending state
+	var stmt2 PreparedStatement
+	assert.Nil(t, tester.conn.Prepare("SELECT CAST(i::VARCHAR || 'x' AS INTEGER) FROM range(10) tbl(i)", &stmt2))
+	defer stmt2.Destroy()
+	var pending2 PendingResult
+	defer pending2.Destroy()
+	assert.Nil(t, stmt2.PendingPrepared(&pending2))
+	state := pending2.ExecuteTask()
+	for state == PendingResultNotReady {
+		state = pending2.ExecuteTask()
+	}
+	assert.Equal(t, PendingError, state)
+	assert.Contains(t, pending2.Error(), "Conversion Error")
+
+	// destroying a pending result aborts its query
+	var stmt3 PreparedStatement
+	assert.Nil(t, tester.conn.Prepare("SELECT COUNT(*) FROM range(100000000000) t1", &stmt3))
+	defer stmt3.Destroy()
+	var pending3 PendingResult
+	assert.Nil(t, stmt3.PendingPrepared(&pending3))
+	assert.Equal(t, PendingResultNotReady, pending3.ExecuteTask())
+	pending3.Destroy()
+	var result3 Result
+	assert.Nil(t, tester.conn.Query("SELECT 42", &result3))
+	assert.Equal(t, int64(42), result3.ValueInt64(0, 0))
+	result3.Destroy()
+
+	// a destroyed or never created pending result cannot be used
+	var empty PendingResult
+	assert.Equal(t, PendingError, empty.ExecuteTask())
+	assert.ErrorIs(t, empty.Execute(nil), ErrDuckDBError)
+}
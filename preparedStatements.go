@@ -5,12 +5,16 @@ package duckdbcapi
 */
 import "C"
 import (
+	"context"
 	"errors"
+	"fmt"
 	"unsafe"
 )
 
 type PreparedStatement struct {
 	c C.duckdb_prepared_statement
+	// names maps $name parameters to their index, see rewriteNamedParams
+	names map[string]uint64
 }
 
 func (p *PreparedStatement) PrepareError() error {
@@ -23,78 +27,101 @@ func (p *PreparedStatement) PrepareError() error {
 
 func (p *PreparedStatement) Destroy() {
 	C.duckdb_destroy_prepare(&p.c)
+	p.names = nil
 }
 
 func (p *PreparedStatement) ExecutePrepared(result *Result) error {
-	var pResult *C.duckdb_result
-	if result != nil {
-		pResult = &result.c
+	if result == nil {
+		var r Result
+		defer r.Destroy()
+		result = &r
 	}
-	if C.duckdb_execute_prepared(p.c, pResult) == C.DuckDBError {
-		return ErrDuckDBError
+	if C.duckdb_execute_prepared(p.c, &result.c) == C.DuckDBError {
+		return newError("execute prepared", "", result.ResultError())
 	}
 	return nil
 }
 
+// ExecuteContext behaves like ExecutePrepared but interrupts the query when
+// ctx is cancelled or its deadline passes, checked between execution
+// tasks. The returned error then wraps ctx.Err().
+func (p *PreparedStatement) ExecuteContext(ctx context.Context, result *Result) error {
+	if err := ctx.Err(); err != nil {
+		return newContextError(err)
+	}
+	var pending PendingResult
+	defer pending.Destroy()
+	if err := p.PendingPrepared(&pending); err != nil {
+		return err
+	}
+	return pending.executeContext(ctx, result)
+}
+
+// bindError reports a failed bind; the C API does not say why, but it fails
+// for an index out of range or a value the parameter type cannot hold.
+func bindError(paramIdx uint64, typ string) error {
+	return newError("bind", "", fmt.Sprintf("cannot bind %s to parameter $%d", typ, paramIdx))
+}
+
 func (p *PreparedStatement) BindBoolean(paramIdx uint64, val bool) error {
 	if C.duckdb_bind_boolean(p.c, C.idx_t(paramIdx), C.bool(val)) == C.DuckDBError {
-		return ErrDuckDBError
+		return bindError(paramIdx, "BOOLEAN")
 	}
 	return nil
 }
 
 func (p *PreparedStatement) BindInt8(paramIdx uint64, val int8) error {
 	if C.duckdb_bind_int8(p.c, C.idx_t(paramIdx), C.schar(val)) == C.DuckDBError {
-		return ErrDuckDBError
+		return bindError(paramIdx, "TINYINT")
 	}
 	return nil
 }
 
 func (p *PreparedStatement) BindInt16(paramIdx uint64, val int16) error {
 	if C.duckdb_bind_int16(p.c, C.idx_t(paramIdx), C.short(val)) == C.DuckDBError {
-		return ErrDuckDBError
+		return bindError(paramIdx, "SMALLINT")
 	}
 	return nil
 }
 
 func (p *PreparedStatement) BindInt32(paramIdx uint64, val int32) error {
 	if C.duckdb_bind_int32(p.c, C.idx_t(paramIdx), C.int(val)) == C.DuckDBError {
-		return ErrDuckDBError
+		return bindError(paramIdx, "INTEGER")
 	}
 	return nil
 }
 
 func (p *PreparedStatement) BindInt64(paramIdx uint64, val int64) error {
 	if C.duckdb_bind_int64(p.c, C.idx_t(paramIdx), C.long(val)) == C.DuckDBError {
-		return ErrDuckDBError
+		return bindError(paramIdx, "BIGINT")
 	}
 	return nil
 }
 
 func (p *PreparedStatement) BindUInt8(paramIdx uint64, val uint8) error {
 	if C.duckdb_bind_uint8(p.c, C.idx_t(paramIdx), C.uchar(val)) == C.DuckDBError {
-		return ErrDuckDBError
+		return bindError(paramIdx, "UTINYINT")
 	}
 	return nil
 }
 
 func (p *PreparedStatement) BindUInt16(paramIdx uint64, val uint16) error {
 	if C.duckdb_bind_uint16(p.c, C.idx_t(paramIdx), C.ushort(val)) == C.DuckDBError {
-		return ErrDuckDBError
+		return bindError(paramIdx, "USMALLINT")
 	}
 	return nil
 }
 
 func (p *PreparedStatement) BindUInt32(paramIdx uint64, val uint32) error {
 	if C.duckdb_bind_uint32(p.c, C.idx_t(paramIdx), C.uint(val)) == C.DuckDBError {
-		return ErrDuckDBError
+		return bindError(paramIdx, "UINTEGER")
 	}
 	return nil
 }
 
 func (p *PreparedStatement) BindUInt64(paramIdx uint64, val uint64) error {
 	if C.duckdb_bind_uint64(p.c, C.idx_t(paramIdx), C.ulong(val)) == C.DuckDBError {
-		return ErrDuckDBError
+		return bindError(paramIdx, "UBIGINT")
 	}
 	return nil
 }
@@ -103,15 +130,18 @@ func (p *PreparedStatement) BindVarChar(paramIdx uint64, val string) error {
 	cVal := C.CString(val)
 	defer C.free(unsafe.Pointer(cVal))
 	if C.duckdb_bind_varchar(p.c, C.idx_t(paramIdx), cVal) == C.DuckDBError {
-		return ErrDuckDBError
+		return bindError(paramIdx, "VARCHAR")
 	}
 	return nil
 }
 func (p *PreparedStatement) BindVarCharLength(paramIdx uint64, val string, length uint64) error {
+	if err := checkVarCharLength(val, length); err != nil {
+		return err
+	}
 	cVal := C.CString(val)
 	defer C.free(unsafe.Pointer(cVal))
 	if C.duckdb_bind_varchar_length(p.c, C.idx_t(paramIdx), cVal, C.idx_t(length)) == C.DuckDBError {
-		return ErrDuckDBError
+		return bindError(paramIdx, "VARCHAR")
 	}
 	return nil
 }
@@ -120,67 +150,78 @@ func (p *PreparedStatement) BindBlob(paramIdx uint64, data []byte) error {
 	pData := C.CBytes(data)
 	defer C.free(unsafe.Pointer(pData))
 	if C.duckdb_bind_blob(p.c, C.idx_t(paramIdx), pData, C.idx_t(len(data))) == C.DuckDBError {
-		return ErrDuckDBError
+		return bindError(paramIdx, "BLOB")
 	}
 	return nil
 }
 
 func (p *PreparedStatement) BindNull(paramIdx uint64) error {
 	if C.duckdb_bind_null(p.c, C.idx_t(paramIdx)) == C.DuckDBError {
-		return ErrDuckDBError
+		return bindError(paramIdx, "NULL")
 	}
 	return nil
 }
 
 func (p *PreparedStatement) BindHugeInt(paramIdx uint64, val HugeInt) error {
 	if C.duckdb_bind_hugeint(p.c, C.idx_t(paramIdx), val.c) == C.DuckDBError {
-		return ErrDuckDBError
+		return bindError(paramIdx, "HUGEINT")
 	}
 	return nil
 }
 
 func (p *PreparedStatement) BindFloat(paramIdx uint64, val Float) error {
 	if C.duckdb_bind_float(p.c, C.idx_t(paramIdx), C.float(val)) == C.DuckDBError {
-		return ErrDuckDBError
+		return bindError(paramIdx, "FLOAT")
 	}
 	return nil
 }
 
 func (p *PreparedStatement) BindDouble(paramIdx uint64, val Double) error {
 	if C.duckdb_bind_double(p.c, C.idx_t(paramIdx), C.double(val)) == C.DuckDBError {
-		return ErrDuckDBError
+		return bindError(paramIdx, "DOUBLE")
 	}
 	return nil
 }
 
 func (p *PreparedStatement) BindDate(paramIdx uint64, val Date) error {
 	if C.duckdb_bind_date(p.c, C.idx_t(paramIdx), val.c) == C.DuckDBError {
-		return ErrDuckDBError
+		return bindError(paramIdx, "DATE")
 	}
 	return nil
 }
 
 func (p *PreparedStatement) BindTime(paramIdx uint64, val Time) error {
 	if C.duckdb_bind_time(p.c, C.idx_t(paramIdx), val.c) == C.DuckDBError {
-		return ErrDuckDBError
+		return bindError(paramIdx, "TIME")
 	}
 	return nil
 }
 
 func (p *PreparedStatement) BindTimestamp(paramIdx uint64, val Timestamp) error {
 	if C.duckdb_bind_timestamp(p.c, C.idx_t(paramIdx), val.c) == C.DuckDBError {
-		return ErrDuckDBError
+		return bindError(paramIdx, "TIMESTAMP")
 	}
 	return nil
 }
 
 func (p *PreparedStatement) BindInterval(paramIdx uint64, val Interval) error {
 	if C.duckdb_bind_interval(p.c, C.idx_t(paramIdx), val.c) == C.DuckDBError {
-		return ErrDuckDBError
+		return bindError(paramIdx, "INTERVAL")
 	}
 	return nil
 }
 
+// BindDecimal binds the exact text form of val, since the v0.3.4 C API has
+// no duckdb_bind_decimal. DuckDB casts it to the parameter type.
+func (p *PreparedStatement) BindDecimal(paramIdx uint64, val Decimal) error {
+	return p.BindVarChar(paramIdx, val.String())
+}
+
+// BindUUID binds the string form of val, which DuckDB casts to UUID.
+func (p *PreparedStatement) BindUUID(paramIdx uint64, val UUID) error {
+	return p.BindVarChar(paramIdx, val.String())
+}
+
 func (p *PreparedStatement) NParams() uint64 {
 	return uint64(C.duckdb_nparams(p.c))
 }
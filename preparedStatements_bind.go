@@ -0,0 +1,238 @@
+package duckdbcapi
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var ErrBindArgument = errors.New("ErrBindArgument")
+
+// Bind binds args to the parameters $1..$n in order. It fails if the
+// number of args does not match NParams.
+func (p *PreparedStatement) Bind(args ...any) error {
+	if n := p.NParams(); uint64(len(args)) != n {
+		return fmt.Errorf("%w: expected %d arguments, got %d", ErrBindArgument, n, len(args))
+	}
+	for i, arg := range args {
+		if err := p.BindAt(uint64(i+1), arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BindAt binds v to parameter idx (1-based), choosing the Bind* method from
+// the Go type of v. Where the Go type is ambiguous, ParamType(idx) decides:
+// an int bound to an INTEGER parameter uses BindInt32, a time.Time bound to a
+// DATE parameter uses BindDate and a time.Duration bound to a TIME parameter
+// uses BindTime. nil and nil pointers bind NULL, other pointers and
+// driver.Valuer implementations bind the value they refer to.
+func (p *PreparedStatement) BindAt(idx uint64, v any) error {
+	if err := p.bindAt(idx, v); err != nil {
+		if errors.Is(err, ErrBindArgument) {
+			return err
+		}
+		return fmt.Errorf("parameter %d: %w", idx, err)
+	}
+	return nil
+}
+
+func (p *PreparedStatement) bindAt(idx uint64, v any) error {
+	switch x := v.(type) {
+	case nil:
+		return p.BindNull(idx)
+	case bool:
+		return p.BindBoolean(idx, x)
+	case int:
+		return p.bindInteger(idx, int64(x))
+	case int8:
+		return p.bindInteger(idx, int64(x))
+	case int16:
+		return p.bindInteger(idx, int64(x))
+	case int32:
+		return p.bindInteger(idx, int64(x))
+	case int64:
+		return p.bindInteger(idx, x)
+	case uint:
+		return p.bindUnsigned(idx, uint64(x))
+	case uint8:
+		return p.bindUnsigned(idx, uint64(x))
+	case uint16:
+		return p.bindUnsigned(idx, uint64(x))
+	case uint32:
+		return p.bindUnsigned(idx, uint64(x))
+	case uint64:
+		return p.bindUnsigned(idx, x)
+	case float32:
+		return p.bindFloat(idx, float64(x))
+	case float64:
+		return p.bindFloat(idx, x)
+	case Float:
+		return p.BindFloat(idx, x)
+	case Double:
+		return p.BindDouble(idx, x)
+	case string:
+		return p.BindVarCharLength(idx, x, uint64(len(x)))
+	case []byte:
+		if x == nil {
+			return p.BindNull(idx)
+		}
+		return p.BindBlob(idx, x)
+	case time.Time:
+		switch p.ParamType(idx) {
+		case DuckDBTypeDate:
+			return p.BindDate(idx, DateFromTime(x))
+		case DuckDBTypeTime:
+			return p.BindTime(idx, TimeFromTime(x))
+		default:
+			return p.BindTimestamp(idx, TimestampFromTime(x))
+		}
+	case time.Duration:
+		if p.ParamType(idx) == DuckDBTypeTime {
+			return p.BindTime(idx, TimeFromDuration(x))
+		}
+		return p.BindInterval(idx, IntervalFromDuration(x))
+	case *big.Int:
+		if x == nil {
+			return p.BindNull(idx)
+		}
+		if p.ParamType(idx) == DuckDBTypeDecimal {
+			return p.BindVarChar(idx, x.String())
+		}
+		h, err := HugeIntFromBig(x)
+		if err != nil {
+			return fmt.Errorf("%w: parameter %d: %v", ErrBindArgument, idx, err)
+		}
+		return p.BindHugeInt(idx, h)
+	case HugeInt:
+		return p.BindHugeInt(idx, x)
+	case Decimal:
+		return p.BindDecimal(idx, x)
+	case UUID:
+		return p.BindUUID(idx, x)
+	case Date:
+		return p.BindDate(idx, x)
+	case Time:
+		return p.BindTime(idx, x)
+	case Timestamp:
+		return p.BindTimestamp(idx, x)
+	case Interval:
+		return p.BindInterval(idx, x)
+	case driver.Valuer:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return p.BindNull(idx)
+		}
+		value, err := x.Value()
+		if err != nil {
+			return fmt.Errorf("%w: parameter %d: %v", ErrBindArgument, idx, err)
+		}
+		return p.bindAt(idx, value)
+	}
+
+	// named types and pointers
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return p.BindNull(idx)
+		}
+		return p.bindAt(idx, rv.Elem().Interface())
+	case reflect.Bool:
+		return p.BindBoolean(idx, rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return p.bindInteger(idx, rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return p.bindUnsigned(idx, rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return p.bindFloat(idx, rv.Float())
+	case reflect.String:
+		return p.bindAt(idx, rv.String())
+	}
+	return fmt.Errorf("%w: parameter %d: unsupported type %T", ErrBindArgument, idx, v)
+}
+
+func (p *PreparedStatement) bindInteger(idx uint64, v int64) error {
+	typ := p.ParamType(idx)
+	inRange := func(min, max int64) error {
+		if v < min || v > max {
+			return fmt.Errorf("%w: parameter %d: %d overflows %s", ErrBindArgument, idx, v, typeName(typ))
+		}
+		return nil
+	}
+	var err error
+	switch typ {
+	case DuckDBTypeTinyInt:
+		if err = inRange(math.MinInt8, math.MaxInt8); err == nil {
+			return p.BindInt8(idx, int8(v))
+		}
+	case DuckDBTypeSmallInt:
+		if err = inRange(math.MinInt16, math.MaxInt16); err == nil {
+			return p.BindInt16(idx, int16(v))
+		}
+	case DuckDBTypeInteger:
+		if err = inRange(math.MinInt32, math.MaxInt32); err == nil {
+			return p.BindInt32(idx, int32(v))
+		}
+	case DuckDBTypeUTinyInt:
+		if err = inRange(0, math.MaxUint8); err == nil {
+			return p.BindUInt8(idx, uint8(v))
+		}
+	case DuckDBTypeUSmallInt:
+		if err = inRange(0, math.MaxUint16); err == nil {
+			return p.BindUInt16(idx, uint16(v))
+		}
+	case DuckDBTypeUInteger:
+		if err = inRange(0, math.MaxUint32); err == nil {
+			return p.BindUInt32(idx, uint32(v))
+		}
+	case DuckDBTypeUBigInt:
+		if err = inRange(0, math.MaxInt64); err == nil {
+			return p.BindUInt64(idx, uint64(v))
+		}
+	case DuckDBTypeHugeInt:
+		return p.BindHugeInt(idx, hugeIntFromInt64(v))
+	case DuckDBTypeFloat, DuckDBTypeDouble:
+		return p.BindDouble(idx, Double(v))
+	case DuckDBTypeDecimal:
+		return p.BindVarChar(idx, strconv.FormatInt(v, 10))
+	default:
+		return p.BindInt64(idx, v)
+	}
+	return err
+}
+
+func (p *PreparedStatement) bindUnsigned(idx uint64, v uint64) error {
+	if v <= math.MaxInt64 {
+		return p.bindInteger(idx, int64(v))
+	}
+	switch typ := p.ParamType(idx); typ {
+	case DuckDBTypeUBigInt, DuckDBTypeInvalid:
+		return p.BindUInt64(idx, v)
+	case DuckDBTypeHugeInt:
+		return p.BindHugeInt(idx, InitHugInt(v, 0))
+	case DuckDBTypeFloat, DuckDBTypeDouble:
+		return p.BindDouble(idx, Double(v))
+	case DuckDBTypeDecimal:
+		return p.BindVarChar(idx, strconv.FormatUint(v, 10))
+	default:
+		return fmt.Errorf("%w: parameter %d: %d overflows %s", ErrBindArgument, idx, v, typeName(typ))
+	}
+}
+
+func (p *PreparedStatement) bindFloat(idx uint64, v float64) error {
+	switch p.ParamType(idx) {
+	case DuckDBTypeFloat:
+		return p.BindFloat(idx, Float(v))
+	case DuckDBTypeDecimal:
+		return p.BindVarChar(idx, strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		return p.BindDouble(idx, Double(v))
+	}
+}
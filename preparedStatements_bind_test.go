@@ -0,0 +1,71 @@
+package duckdbcapi
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type bindLevel int16
+
+func TestGenericBindInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+	assert.Nil(t, tester.NoResultQuery(`CREATE TABLE args (t TINYINT, i INTEGER, u UBIGINT, h HUGEINT,
+		f FLOAT, dec DECIMAL(9,2), s VARCHAR, b BLOB, d DATE, ts TIMESTAMP, iv INTERVAL, id UUID, lvl SMALLINT)`))
+
+	uuid, _ := ParseUUID("a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11")
+	price, _ := ParseDecimal("12.34", 9, 2)
+	huge, _ := new(big.Int).SetString("170141183460469231731687303715884105727", 10)
+	day := time.Date(2022, 5, 1, 23, 30, 0, 0, time.UTC)
+	name := "alice"
+	changed, err := tester.conn.Exec("INSERT INTO args VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)",
+		-5, int64(7), uint64(1<<64-1), huge, 1.5, price, &name, []byte{0, 1}, day, day, 90*time.Second, uuid, bindLevel(3))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), changed)
+
+	changed, err = tester.conn.Exec("INSERT INTO args (t, s, dec) VALUES (?, ?, ?)", nil, (*string)(nil), 0.5)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), changed)
+
+	var result Result
+	assert.Nil(t, tester.conn.QueryArgs("SELECT t, i, u::VARCHAR, h::VARCHAR, f, dec::VARCHAR, s, b, d, ts, iv, id::VARCHAR, lvl FROM args WHERE i = $1", &result, 7))
+	defer result.Destroy()
+	assert.Equal(t, uint64(1), result.RowCount())
+	assert.Equal(t, int8(-5), result.ValueInt8(0, 0))
+	assert.Equal(t, "18446744073709551615", result.ValueVarChar(2, 0))
+	assert.Equal(t, huge.String(), result.ValueVarChar(3, 0))
+	assert.Equal(t, Float(1.5), result.ValueFloat(4, 0))
+	assert.Equal(t, "12.34", result.ValueVarChar(5, 0))
+	assert.Equal(t, "alice", result.ValueVarChar(6, 0))
+	d := result.ValueDate(8, 0)
+	assert.Equal(t, time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC), d.Time())
+	ts := result.ValueTimestamp(9, 0)
+	assert.Equal(t, day, ts.Time())
+	iv := result.ValueInterval(10, 0)
+	assert.Equal(t, int64(90000000), iv.Micros())
+	assert.Equal(t, uuid.String(), result.ValueVarChar(11, 0))
+	assert.Equal(t, int16(3), result.ValueInt16(12, 0))
+
+	var nulls Result
+	assert.Nil(t, tester.conn.QueryArgs("SELECT t, s, dec::VARCHAR FROM args WHERE i IS NULL", &nulls))
+	defer nulls.Destroy()
+	assert.Equal(t, true, nulls.ValueIsNull(0, 0))
+	assert.Equal(t, true, nulls.ValueIsNull(1, 0))
+	assert.Equal(t, "0.50", nulls.ValueVarChar(2, 0))
+
+	_, err = tester.conn.Exec("INSERT INTO args (t) VALUES ($1)", 300)
+	assert.ErrorIs(t, err, ErrBindArgument)
+	assert.Contains(t, err.Error(), "parameter 1")
+	_, err = tester.conn.Exec("INSERT INTO args (t) VALUES ($1)", struct{}{})
+	assert.ErrorIs(t, err, ErrBindArgument)
+	_, err = tester.conn.Exec("INSERT INTO args (t) VALUES ($1)")
+	assert.ErrorIs(t, err, ErrBindArgument)
+	_, err = tester.conn.Exec("INSERT INTO args (t) VALUES ($1)", 1, 2)
+	assert.ErrorIs(t, err, ErrBindArgument)
+	_, err = tester.conn.Exec("INSERT INTO nope VALUES ($1)", 1)
+	assert.ErrorIs(t, err, ErrDuckDBError)
+}
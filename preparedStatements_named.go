@@ -0,0 +1,233 @@
+package duckdbcapi
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrNamedParam = errors.New("ErrNamedParam")
+
+// rewriteNamedParams replaces every $name parameter in query with a
+// positional $n, numbering names in order of first appearance. The v0.3.4
+// parser only understands positional parameters and the C API has no
+// parameter name lookup, so the query is tokenized here: string literals,
+// dollar-quoted strings, quoted identifiers and comments are copied
+// unchanged. Queries without named parameters are returned as is; $n
+// parameters cannot be mixed with named ones, and ? is left alone since it
+// is also an operator.
+func rewriteNamedParams(query string) (string, map[string]uint64, error) {
+	var (
+		out        strings.Builder
+		names      map[string]uint64
+		positional bool
+		last       int
+	)
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '\'':
+			escapes := i > 0 && (query[i-1] == 'E' || query[i-1] == 'e') && (i == 1 || !isIdentifierByte(query[i-2]))
+			i = skipQuoted(query, i, '\'', escapes)
+		case c == '"':
+			i = skipQuoted(query, i, '"', false)
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			if end := strings.IndexByte(query[i:], '\n'); end >= 0 {
+				i += end + 1
+			} else {
+				i = len(query)
+			}
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			i = skipBlockComment(query, i)
+		case c == '$':
+			end := i + 1
+			for end < len(query) && isIdentifierByte(query[end]) {
+				end++
+			}
+			word := query[i+1 : end]
+			switch {
+			case end < len(query) && query[end] == '$' && (word == "" || !isDigit(word[0])):
+				// $$...$$ or $tag$...$tag$
+				tag := query[i : end+1]
+				if close := strings.Index(query[end+1:], tag); close >= 0 {
+					i = end + 1 + close + len(tag)
+				} else {
+					i = len(query)
+				}
+			case word == "" || isIdentifierByte(prevByte(query, i)):
+				i = end
+			case isDigit(word[0]):
+				if _, err := strconv.ParseUint(word, 10, 64); err != nil {
+					return "", nil, fmt.Errorf("%w: invalid parameter $%s", ErrNamedParam, word)
+				}
+				positional = true
+				i = end
+			default:
+				if names == nil {
+					names = map[string]uint64{}
+				}
+				idx, ok := names[word]
+				if !ok {
+					idx = uint64(len(names) + 1)
+					names[word] = idx
+				}
+				out.WriteString(query[last:i])
+				out.WriteString("$" + strconv.FormatUint(idx, 10))
+				last = end
+				i = end
+			}
+		default:
+			i++
+		}
+	}
+	if names == nil {
+		return query, nil, nil
+	}
+	if positional {
+		return "", nil, fmt.Errorf("%w: cannot mix named and positional parameters", ErrNamedParam)
+	}
+	out.WriteString(query[last:])
+	return out.String(), names, nil
+}
+
+func skipQuoted(query string, i int, quote byte, escapes bool) int {
+	for i++; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			if escapes {
+				i++
+			}
+		case quote:
+			if i+1 < len(query) && query[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(query)
+}
+
+// skipBlockComment skips a /* */ comment, which may be nested.
+func skipBlockComment(query string, i int) int {
+	depth := 0
+	for i < len(query) {
+		switch {
+		case strings.HasPrefix(query[i:], "/*"):
+			depth++
+			i += 2
+		case strings.HasPrefix(query[i:], "*/"):
+			depth--
+			i += 2
+			if depth == 0 {
+				return i
+			}
+		default:
+			i++
+		}
+	}
+	return len(query)
+}
+
+func prevByte(s string, i int) byte {
+	if i == 0 {
+		return 0
+	}
+	return s[i-1]
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// PrepareNamed prepares a query with named parameters such as $user_id,
+// which are bound with BindNamed, BindStruct or BindAt(ParamIndex(name)).
+// The v0.3.4 parser does not accept them, so they are rewritten to $1..$n
+// first.
+func (c *Connection) PrepareNamed(query string, stmt *PreparedStatement) error {
+	rewritten, names, err := rewriteNamedParams(query)
+	if err != nil {
+		return err
+	}
+	if err := c.Prepare(rewritten, stmt); err != nil {
+		if e, ok := err.(*Error); ok {
+			e.Query = query
+		}
+		return err
+	}
+	stmt.names = names
+	return nil
+}
+
+// ParamNames returns the named parameters of the statement in index order,
+// or nil if it was prepared with positional parameters.
+func (p *PreparedStatement) ParamNames() []string {
+	if p.names == nil {
+		return nil
+	}
+	names := make([]string, 0, len(p.names))
+	for name := range p.names {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return p.names[names[i]] < p.names[names[j]] })
+	return names
+}
+
+// ParamIndex returns the index of the named parameter $name.
+func (p *PreparedStatement) ParamIndex(name string) (uint64, error) {
+	idx, ok := p.names[strings.TrimPrefix(name, "$")]
+	if !ok {
+		return 0, fmt.Errorf("%w: unknown parameter $%s", ErrNamedParam, strings.TrimPrefix(name, "$"))
+	}
+	return idx, nil
+}
+
+// BindNamed binds every named parameter of the statement from args, keyed
+// by name without the leading $. Missing and unknown names are errors.
+func (p *PreparedStatement) BindNamed(args map[string]any) error {
+	for name := range args {
+		if _, err := p.ParamIndex(name); err != nil {
+			return err
+		}
+	}
+	for _, name := range p.ParamNames() {
+		v, ok := args[name]
+		if !ok {
+			v, ok = args["$"+name]
+		}
+		if !ok {
+			return fmt.Errorf("%w: missing argument for $%s", ErrNamedParam, name)
+		}
+		if err := p.bindAt(p.names[name], v); err != nil {
+			return fmt.Errorf("parameter $%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// BindStruct binds every named parameter of the statement from the fields
+// of arg, a struct or pointer to struct. Fields are matched like in
+// ScanStructs: by `duckdb:"name"` tag or case-insensitive field name.
+func (p *PreparedStatement) BindStruct(arg any) error {
+	v := reflect.ValueOf(arg)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T is not a struct", ErrBindArgument, arg)
+	}
+	plan := structPlanFor(v.Type())
+	for _, name := range p.ParamNames() {
+		index := plan.lookup(name)
+		if index == nil {
+			return fmt.Errorf("%w: no field for $%s in %s", ErrNamedParam, name, v.Type())
+		}
+		if err := p.bindAt(p.names[name], v.FieldByIndex(index).Interface()); err != nil {
+			return fmt.Errorf("parameter $%s: %w", name, err)
+		}
+	}
+	return nil
+}
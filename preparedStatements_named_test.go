@@ -0,0 +1,88 @@
+package duckdbcapi
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriteNamedParams(t *testing.T) {
+	query, names, err := rewriteNamedParams(`SELECT $user_id, '$quoted', "$ident", $$ $dollar $$ -- $comment
+		/* $block /* nested */ */ $name::VARCHAR, $user_id`)
+	assert.Nil(t, err)
+	assert.Equal(t, `SELECT $1, '$quoted', "$ident", $$ $dollar $$ -- $comment
+		/* $block /* nested */ */ $2::VARCHAR, $1`, query)
+	assert.Equal(t, map[string]uint64{"user_id": 1, "name": 2}, names)
+
+	query, names, err = rewriteNamedParams("SELECT 'it''s $x', $1, ?")
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT 'it''s $x', $1, ?", query)
+	assert.Nil(t, names)
+
+	// ? is also an operator, so it does not count as a positional parameter
+	query, _, err = rewriteNamedParams("SELECT $doc ? 'key'")
+	assert.Nil(t, err)
+	assert.Equal(t, "SELECT $1 ? 'key'", query)
+
+	_, _, err = rewriteNamedParams("SELECT $1, $name")
+	assert.ErrorIs(t, err, ErrNamedParam)
+}
+
+func TestNamedParamsInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+	assert.Nil(t, tester.NoResultQuery("CREATE TABLE users (id INTEGER, name VARCHAR)"))
+
+	var stmt PreparedStatement
+	assert.NotNil(t, tester.conn.Prepare("INSERT INTO users VALUES ($user_id, $name)", &stmt))
+	stmt.Destroy()
+	assert.Nil(t, tester.conn.PrepareNamed("INSERT INTO users VALUES ($user_id, $name)", &stmt))
+	defer stmt.Destroy()
+	assert.Equal(t, []string{"user_id", "name"}, stmt.ParamNames())
+	idx, err := stmt.ParamIndex("$name")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), idx)
+
+	assert.Nil(t, stmt.BindNamed(map[string]any{"user_id": 1, "name": "alice"}))
+	assert.Nil(t, stmt.ExecutePrepared(nil))
+	assert.ErrorIs(t, stmt.BindNamed(map[string]any{"user_id": 2}), ErrNamedParam)
+	assert.ErrorIs(t, stmt.BindNamed(map[string]any{"user_id": 2, "name": "bob", "age": 3}), ErrNamedParam)
+
+	type user struct {
+		ID   int `duckdb:"user_id"`
+		Name string
+	}
+	assert.Nil(t, stmt.BindStruct(&user{ID: 2, Name: "bob"}))
+	assert.Nil(t, stmt.ExecutePrepared(nil))
+	assert.ErrorIs(t, stmt.BindStruct(struct{ Name string }{}), ErrNamedParam)
+	assert.ErrorIs(t, stmt.BindStruct(1), ErrBindArgument)
+
+	var result Result
+	assert.Nil(t, tester.conn.Query("SELECT name FROM users ORDER BY id", &result))
+	defer result.Destroy()
+	assert.Equal(t, uint64(2), result.RowCount())
+	assert.Equal(t, "alice", result.ValueVarChar(0, 0))
+	assert.Equal(t, "bob", result.ValueVarChar(0, 1))
+
+	var positional PreparedStatement
+	assert.ErrorIs(t, tester.conn.PrepareNamed("SELECT $1, $name", &positional), ErrNamedParam)
+	assert.Nil(t, tester.conn.PrepareNamed("SELECT $1", &positional))
+	assert.Nil(t, positional.ParamNames())
+	positional.Destroy()
+}
+
+func TestSQLDriverNamedArgs(t *testing.T) {
+	db, err := sql.Open("duckdb-capi", "")
+	assert.Nil(t, err)
+	defer db.Close()
+
+	var name string
+	var id int
+	assert.Nil(t, db.QueryRow("SELECT $name, $id + 1", sql.Named("id", 41), sql.Named("name", "alice")).Scan(&name, &id))
+	assert.Equal(t, "alice", name)
+	assert.Equal(t, 42, id)
+
+	assert.ErrorIs(t, db.QueryRow("SELECT $name", sql.Named("other", 1)).Scan(&name), ErrNamedParam)
+}
@@ -5,6 +5,7 @@ package duckdbcapi
 */
 import "C"
 import (
+	"time"
 	"unsafe"
 )
 
@@ -170,3 +171,55 @@ func (r *Result) ValueDecimal(col, row uint64) Decimal {
 func (r *Result) ValueInterval(col, row uint64) Interval {
 	return Interval{C.duckdb_value_interval(&r.c, C.idx_t(col), C.idx_t(row))}
 }
+
+// resultValue converts a single cell of a materialized result into the
+// closest Go type.
+func resultValue(r *Result, col, row uint64) any {
+	if r.ValueIsNull(col, row) {
+		return nil
+	}
+	switch r.ColumnType(col) {
+	case DuckDBTypeBoolean:
+		return r.ValueBoolean(col, row)
+	case DuckDBTypeTinyInt:
+		return r.ValueInt8(col, row)
+	case DuckDBTypeSmallInt:
+		return r.ValueInt16(col, row)
+	case DuckDBTypeInteger:
+		return r.ValueInt32(col, row)
+	case DuckDBTypeBigInt:
+		return r.ValueInt64(col, row)
+	case DuckDBTypeUTinyInt:
+		return r.ValueUInt8(col, row)
+	case DuckDBTypeUSmallInt:
+		return r.ValueUInt16(col, row)
+	case DuckDBTypeUInteger:
+		return r.ValueUInt32(col, row)
+	case DuckDBTypeUBigInt:
+		return r.ValueUInt64(col, row)
+	case DuckDBTypeFloat:
+		return float32(r.ValueFloat(col, row))
+	case DuckDBTypeDouble:
+		return float64(r.ValueDouble(col, row))
+	case DuckDBTypeHugeInt:
+		h := r.ValueHugeInt(col, row)
+		return h.BigInt()
+	case DuckDBTypeDate:
+		d := r.ValueDate(col, row)
+		return d.Time()
+	case DuckDBTypeTime:
+		t := r.ValueTime(col, row)
+		return time.UnixMicro(t.Micros()).UTC()
+	case DuckDBTypeTimestamp, DuckDBTypeTimestamp_S, DuckDBTypeTimestamp_MS, DuckDBTypeTimestamp_NS:
+		ts := r.ValueTimestamp(col, row)
+		return ts.Time()
+	case DuckDBTypeInterval:
+		return r.ValueInterval(col, row)
+	case DuckDBTypeBlob:
+		b := r.ValueBlob(col, row)
+		defer b.Free()
+		return append([]byte{}, b.UnsafeDataToSlice()...)
+	default:
+		return r.ValueVarChar(col, row)
+	}
+}
@@ -0,0 +1,363 @@
+package duckdbcapi
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrRowsNoRow          = errors.New("ErrRowsNoRow")
+	ErrRowsColumnMismatch = errors.New("ErrRowsColumnMismatch")
+)
+
+// Rows is a cursor over a materialized Result:
+//
+//	rows := result.Rows()
+//	for rows.Next() {
+//		if err := rows.Scan(&id, &name); err != nil { ... }
+//	}
+//
+// The Result stays owned by the caller and must outlive the Rows.
+type Rows struct {
+	res     *Result
+	row     uint64
+	started bool
+}
+
+type ColumnType struct {
+	Name string
+	Type Type
+	// Width and Scale are only set for DECIMAL columns.
+	Width uint8
+	Scale uint8
+}
+
+func (c *ColumnType) DatabaseTypeName() string {
+	if c.Type == DuckDBTypeDecimal {
+		return fmt.Sprintf("DECIMAL(%d,%d)", c.Width, c.Scale)
+	}
+	return typeName(c.Type)
+}
+
+func (r *Result) Rows() *Rows {
+	return &Rows{res: r}
+}
+
+func (r *Rows) Columns() []string {
+	columns := make([]string, r.res.ColumnCount())
+	for i := range columns {
+		columns[i], _ = r.res.ColumnName(uint64(i))
+	}
+	return columns
+}
+
+func (r *Rows) ColumnTypes() []*ColumnType {
+	types := make([]*ColumnType, r.res.ColumnCount())
+	for i := range types {
+		col := uint64(i)
+		name, _ := r.res.ColumnName(col)
+		lt := r.res.ColumnLogicalType(col)
+		types[i] = &ColumnType{
+			Name:  name,
+			Type:  lt.GetTypeId(),
+			Width: lt.DecimalWidth(),
+			Scale: lt.DecimalScale(),
+		}
+		lt.Destroy()
+	}
+	return types
+}
+
+// Next advances to the next row and reports whether there is one.
+func (r *Rows) Next() bool {
+	if r.started {
+		r.row++
+	}
+	r.started = true
+	return r.row < r.res.RowCount()
+}
+
+// Scan copies the columns of the current row into dest. Supported targets
+// are pointers to Go primitives, string, []byte, time.Time, *big.Int, any,
+// sql.Scanner implementers (including the sql.Null* types) and pointers to
+// pointers, which are set to nil for NULL values.
+func (r *Rows) Scan(dest ...any) error {
+	if !r.started || r.row >= r.res.RowCount() {
+		return ErrRowsNoRow
+	}
+	if uint64(len(dest)) != r.res.ColumnCount() {
+		return fmt.Errorf("%w: expected %d destinations, got %d", ErrRowsColumnMismatch, r.res.ColumnCount(), len(dest))
+	}
+	for i, d := range dest {
+		if err := convertAssign(d, resultValue(r.res, uint64(i), r.row)); err != nil {
+			name, _ := r.res.ColumnName(uint64(i))
+			return fmt.Errorf("column %d (%s): %w", i, name, err)
+		}
+	}
+	return nil
+}
+
+// driverValue narrows src to driver.Value types: integers to int64, or a
+// string when they do not fit, float32 to float64 and intervals to
+// nanoseconds, or a string when they have months or days.
+func driverValue(src any) driver.Value {
+	switch v := src.(type) {
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case uint8:
+		return int64(v)
+	case uint16:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	case uint64:
+		if v <= 1<<63-1 {
+			return int64(v)
+		}
+		return strconv.FormatUint(v, 10)
+	case float32:
+		return float64(v)
+	case *big.Int:
+		if v.IsInt64() {
+			return v.Int64()
+		}
+		return v.String()
+	case Interval:
+		// nanoseconds scan into time.Duration; months and days have no
+		// fixed length
+		if d, ok := v.Duration(); ok {
+			return int64(d)
+		}
+		return fmt.Sprintf("%d months %d days %d microseconds", v.Months(), v.Days(), v.Micros())
+	}
+	return src
+}
+
+func convertAssign(dest, src any) error {
+	if scanner, ok := dest.(sql.Scanner); ok {
+		return scanner.Scan(driverValue(src))
+	}
+	switch d := dest.(type) {
+	case *any:
+		*d = src
+		return nil
+	case *string:
+		switch s := src.(type) {
+		case string:
+			*d = s
+			return nil
+		case []byte:
+			*d = string(s)
+			return nil
+		case time.Time:
+			*d = s.Format(time.RFC3339Nano)
+			return nil
+		case nil:
+		default:
+			*d = fmt.Sprint(s)
+			return nil
+		}
+	case *[]byte:
+		switch s := src.(type) {
+		case []byte:
+			*d = append([]byte{}, s...)
+			return nil
+		case string:
+			*d = []byte(s)
+			return nil
+		case nil:
+			*d = nil
+			return nil
+		}
+	case *time.Time:
+		if s, ok := src.(time.Time); ok {
+			*d = s
+			return nil
+		}
+	case *big.Int:
+		switch s := src.(type) {
+		case *big.Int:
+			d.Set(s)
+			return nil
+		case string:
+			if _, ok := d.SetString(s, 10); ok {
+				return nil
+			}
+		default:
+			if i, ok := asInt64(src); ok {
+				d.SetInt64(i)
+				return nil
+			}
+			if u, ok := src.(uint64); ok {
+				d.SetUint64(u)
+				return nil
+			}
+		}
+	}
+
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() {
+		return fmt.Errorf("destination %T is not a non-nil pointer", dest)
+	}
+	dv = dv.Elem()
+	if src == nil {
+		switch dv.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+			dv.Set(reflect.Zero(dv.Type()))
+			return nil
+		}
+		return fmt.Errorf("cannot scan NULL into %T", dest)
+	}
+	sv := reflect.ValueOf(src)
+	if sv.Type().AssignableTo(dv.Type()) {
+		dv.Set(sv)
+		return nil
+	}
+	if dv.Kind() == reflect.Ptr {
+		v := reflect.New(dv.Type().Elem())
+		if err := convertAssign(v.Interface(), src); err != nil {
+			return err
+		}
+		dv.Set(v)
+		return nil
+	}
+	switch dv.Kind() {
+	case reflect.Bool:
+		switch s := src.(type) {
+		case bool:
+			dv.SetBool(s)
+			return nil
+		case string:
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return err
+			}
+			dv.SetBool(b)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := asInt64(src)
+		if !ok {
+			if s, isString := src.(string); isString {
+				parsed, err := strconv.ParseInt(s, 10, dv.Type().Bits())
+				if err != nil {
+					return err
+				}
+				i, ok = parsed, true
+			}
+		}
+		if ok {
+			if dv.OverflowInt(i) {
+				return fmt.Errorf("value %d overflows %s", i, dv.Type())
+			}
+			dv.SetInt(i)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, ok := asUint64(src)
+		if !ok {
+			if s, isString := src.(string); isString {
+				parsed, err := strconv.ParseUint(s, 10, dv.Type().Bits())
+				if err != nil {
+					return err
+				}
+				u, ok = parsed, true
+			}
+		}
+		if ok {
+			if dv.OverflowUint(u) {
+				return fmt.Errorf("value %d overflows %s", u, dv.Type())
+			}
+			dv.SetUint(u)
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		switch s := src.(type) {
+		case float32:
+			dv.SetFloat(float64(s))
+			return nil
+		case float64:
+			dv.SetFloat(s)
+			return nil
+		case string:
+			f, err := strconv.ParseFloat(s, dv.Type().Bits())
+			if err != nil {
+				return err
+			}
+			dv.SetFloat(f)
+			return nil
+		}
+		if i, ok := asInt64(src); ok {
+			dv.SetFloat(float64(i))
+			return nil
+		}
+		if u, ok := asUint64(src); ok {
+			dv.SetFloat(float64(u))
+			return nil
+		}
+	case reflect.String:
+		var s string
+		if err := convertAssign(&s, src); err != nil {
+			return err
+		}
+		dv.SetString(s)
+		return nil
+	}
+	return fmt.Errorf("cannot scan %T into %T", src, dest)
+}
+
+func asInt64(src any) (int64, bool) {
+	switch v := src.(type) {
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case int:
+		return int64(v), true
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case uint64:
+		if v <= 1<<63-1 {
+			return int64(v), true
+		}
+	case *big.Int:
+		if v.IsInt64() {
+			return v.Int64(), true
+		}
+	}
+	return 0, false
+}
+
+func asUint64(src any) (uint64, bool) {
+	switch v := src.(type) {
+	case uint64:
+		return v, true
+	case *big.Int:
+		if v.IsUint64() {
+			return v.Uint64(), true
+		}
+		return 0, false
+	}
+	if i, ok := asInt64(src); ok && i >= 0 {
+		return uint64(i), true
+	}
+	return 0, false
+}
@@ -0,0 +1,88 @@
+package duckdbcapi
+
+import (
+	"database/sql"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRowsScanInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+
+	var result Result
+	assert.Nil(t, tester.conn.Query(`SELECT 42::INTEGER AS i, 'hello' AS s, '\x01\x02'::BLOB AS b,
+		DATE '1992-09-03' AS d, TIMESTAMP '1992-09-03 12:22:33.1234' AS ts, 1.5::DOUBLE AS f,
+		true AS bo, 170141183460469231731687303715884105727::HUGEINT AS h, 12.34::DECIMAL(9,2) AS dec,
+		NULL::INTEGER AS n, 300::UINTEGER AS u`, &result))
+	defer result.Destroy()
+
+	rows := result.Rows()
+	assert.Equal(t, []string{"i", "s", "b", "d", "ts", "f", "bo", "h", "dec", "n", "u"}, rows.Columns())
+	types := rows.ColumnTypes()
+	assert.Equal(t, "i", types[0].Name)
+	assert.Equal(t, DuckDBTypeInteger, types[0].Type)
+	assert.Equal(t, "DECIMAL(9,2)", types[8].DatabaseTypeName())
+	assert.Equal(t, "VARCHAR", types[1].DatabaseTypeName())
+
+	assert.ErrorIs(t, rows.Scan(), ErrRowsNoRow)
+	assert.Equal(t, true, rows.Next())
+
+	var (
+		i   int
+		s   string
+		b   []byte
+		d   time.Time
+		ts  time.Time
+		f   float64
+		bo  bool
+		h   big.Int
+		dec string
+		n   sql.NullInt64
+		u   uint16
+	)
+	assert.Nil(t, rows.Scan(&i, &s, &b, &d, &ts, &f, &bo, &h, &dec, &n, &u))
+	assert.Equal(t, 42, i)
+	assert.Equal(t, "hello", s)
+	assert.Equal(t, []byte{1, 2}, b)
+	assert.Equal(t, time.Date(1992, 9, 3, 0, 0, 0, 0, time.UTC), d)
+	assert.Equal(t, time.Date(1992, 9, 3, 12, 22, 33, 123400000, time.UTC), ts)
+	assert.Equal(t, 1.5, f)
+	assert.Equal(t, true, bo)
+	maxHugeInt, _ := new(big.Int).SetString("170141183460469231731687303715884105727", 10)
+	assert.Equal(t, 0, maxHugeInt.Cmp(&h))
+	assert.Equal(t, "12.34", dec)
+	assert.Equal(t, false, n.Valid)
+	assert.Equal(t, uint16(300), u)
+
+	t.Run("conversions", func(t *testing.T) {
+		var (
+			i64  sql.NullInt64
+			str  *string
+			anyV any
+			nPtr *int
+			f32  float32
+			u8   uint8
+			i8   int8
+		)
+		assert.Nil(t, rows.Scan(&i64, &str, &anyV, &anyV, &anyV, &f32, &anyV, &anyV, &anyV, &nPtr, &anyV))
+		assert.Equal(t, true, i64.Valid)
+		assert.Equal(t, int64(42), i64.Int64)
+		assert.Equal(t, "hello", *str)
+		assert.Nil(t, nPtr)
+		assert.Equal(t, float32(1.5), f32)
+
+		var skip any
+		assert.Error(t, rows.Scan(&skip, &skip, &skip, &skip, &skip, &skip, &skip, &skip, &skip, &i, &skip))
+		assert.Error(t, rows.Scan(&skip, &skip, &skip, &skip, &skip, &skip, &skip, &skip, &skip, &skip, &i8))
+		assert.Error(t, rows.Scan(&u8, &skip, &skip, &skip, &skip, &skip, &skip, &skip, &skip, &skip, &u8))
+		assert.ErrorIs(t, rows.Scan(&skip), ErrRowsColumnMismatch)
+	})
+
+	assert.Equal(t, false, rows.Next())
+	assert.ErrorIs(t, rows.Scan(&i), ErrRowsNoRow)
+}
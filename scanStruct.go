@@ -0,0 +1,291 @@
+package duckdbcapi
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var ErrScanStructType = errors.New("ErrScanStructType")
+
+// structPlan maps column names to struct fields. Plans are built once per
+// struct type and cached.
+type structPlan struct {
+	byTag  map[string][]int
+	byName map[string][]int
+}
+
+var structPlans sync.Map
+
+func structPlanFor(t reflect.Type) *structPlan {
+	if plan, ok := structPlans.Load(t); ok {
+		return plan.(*structPlan)
+	}
+	tags, names := map[string][][]int{}, map[string][][]int{}
+	collectFields(t, nil, tags, names)
+	plan := &structPlan{byTag: dominantFields(tags), byName: dominantFields(names)}
+	actual, _ := structPlans.LoadOrStore(t, plan)
+	return actual.(*structPlan)
+}
+
+// collectFields records every field of t, including those promoted from
+// embedded structs, under its tag or lower-cased name.
+func collectFields(t reflect.Type, parent []int, tags, names map[string][][]int) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		index := append(append([]int{}, parent...), i)
+		tag := f.Tag.Get("duckdb")
+		if tag == "-" {
+			continue
+		}
+		if f.Anonymous && tag == "" && f.Type.Kind() == reflect.Struct {
+			collectFields(f.Type, index, tags, names)
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if tag != "" {
+			tags[tag] = append(tags[tag], index)
+			continue
+		}
+		name := strings.ToLower(f.Name)
+		names[name] = append(names[name], index)
+	}
+}
+
+// dominantFields applies Go's promotion rules like encoding/json: the
+// shallowest field of a name wins, and a name with several fields at that
+// depth is ambiguous and dropped.
+func dominantFields(fields map[string][][]int) map[string][]int {
+	dominant := make(map[string][]int, len(fields))
+	for name, candidates := range fields {
+		var best []int
+		ambiguous := false
+		for _, index := range candidates {
+			switch {
+			case best == nil || len(index) < len(best):
+				best, ambiguous = index, false
+			case len(index) == len(best):
+				ambiguous = true
+			}
+		}
+		if !ambiguous {
+			dominant[name] = best
+		}
+	}
+	return dominant
+}
+
+// lookup returns the field for a column: the shallower of an exact
+// `duckdb:"col"` tag match and a case-insensitive field name match, with
+// the tag winning at the same depth.
+func (p *structPlan) lookup(column string) []int {
+	tagged, ok := p.byTag[column]
+	named := p.byName[strings.ToLower(column)]
+	if ok && (named == nil || len(tagged) <= len(named)) {
+		return tagged
+	}
+	return named
+}
+
+// ScanStructs decodes every row of res into a T, mapping columns to fields
+// by `duckdb:"col"` tag or case-insensitive field name. STRUCT columns
+// decode into nested structs (or maps), LIST columns into slices and MAP
+// columns into maps. Columns without a matching field are skipped.
+//
+// ScanStructs reads the result through its data chunks, so it cannot be
+// combined with the Result.Value* API on the same result.
+func ScanStructs[T any](res *Result) ([]T, error) {
+	return scanStructs[T](res, -1)
+}
+
+// ScanStruct decodes the first row of res into a T.
+func ScanStruct[T any](res *Result) (T, error) {
+	var zero T
+	rows, err := scanStructs[T](res, 1)
+	if err != nil {
+		return zero, err
+	}
+	if len(rows) == 0 {
+		return zero, ErrRowsNoRow
+	}
+	return rows[0], nil
+}
+
+func scanStructs[T any](res *Result, limit int) ([]T, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %s is not a struct", ErrScanStructType, t)
+	}
+	plan := structPlanFor(t)
+	columns := make([]string, res.ColumnCount())
+	fields := make([][]int, res.ColumnCount())
+	for i := range fields {
+		columns[i], _ = res.ColumnName(uint64(i))
+		fields[i] = plan.lookup(columns[i])
+	}
+
+	capacity := res.RowCount()
+	if limit >= 0 && uint64(limit) < capacity {
+		capacity = uint64(limit)
+	}
+	out := make([]T, 0, capacity)
+	for c := uint64(0); c < res.ChunkCount() && (limit < 0 || len(out) < limit); c++ {
+		chunk, err := res.Chunk(c)
+		if err != nil {
+			return nil, err
+		}
+		out, err = scanChunk(chunk, columns, fields, out, limit)
+		chunk.Destroy()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func scanChunk[T any](chunk *DataChunk, columns []string, fields [][]int, out []T, limit int) ([]T, error) {
+	readers := make([]*vectorReader, len(fields))
+	defer func() {
+		for _, r := range readers {
+			if r != nil {
+				r.destroy()
+			}
+		}
+	}()
+	for col, index := range fields {
+		if index == nil {
+			continue
+		}
+		vec, err := chunk.GetVector(uint64(col))
+		if err != nil {
+			return out, err
+		}
+		if readers[col], err = newVectorReader(vec); err != nil {
+			return out, err
+		}
+	}
+	size := chunk.GetSize()
+	for row := uint64(0); row < size && (limit < 0 || len(out) < limit); row++ {
+		var v T
+		rv := reflect.ValueOf(&v).Elem()
+		for col, index := range fields {
+			if index == nil {
+				continue
+			}
+			if err := assignValue(rv.FieldByIndex(index), readers[col].value(row)); err != nil {
+				return out, fmt.Errorf("row %d, column %s: %w", len(out), columns[col], err)
+			}
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+// assignValue stores a value produced by vectorReader into dst, recursing
+// into nested structs, slices and maps.
+func assignValue(dst reflect.Value, src any) error {
+	if src == nil {
+		switch dst.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		return convertAssign(dst.Addr().Interface(), nil)
+	}
+	if dst.Kind() == reflect.Interface && dst.NumMethod() == 0 {
+		dst.Set(reflect.ValueOf(src))
+		return nil
+	}
+	switch s := src.(type) {
+	case map[string]any:
+		switch dst.Kind() {
+		case reflect.Ptr:
+			v := reflect.New(dst.Type().Elem())
+			if err := assignValue(v.Elem(), src); err != nil {
+				return err
+			}
+			dst.Set(v)
+			return nil
+		case reflect.Struct:
+			plan := structPlanFor(dst.Type())
+			for name, value := range s {
+				index := plan.lookup(name)
+				if index == nil {
+					continue
+				}
+				if err := assignValue(dst.FieldByIndex(index), value); err != nil {
+					return fmt.Errorf("field %s: %w", name, err)
+				}
+			}
+			return nil
+		case reflect.Map:
+			return assignMap(dst, len(s), func(set func(k, v any) error) error {
+				for k, v := range s {
+					if err := set(k, v); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}
+	case map[any]any:
+		if dst.Kind() == reflect.Map {
+			return assignMap(dst, len(s), func(set func(k, v any) error) error {
+				for k, v := range s {
+					if err := set(k, v); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}
+	case []any:
+		switch dst.Kind() {
+		case reflect.Slice:
+			list := reflect.MakeSlice(dst.Type(), len(s), len(s))
+			for i, elem := range s {
+				if err := assignValue(list.Index(i), elem); err != nil {
+					return fmt.Errorf("element %d: %w", i, err)
+				}
+			}
+			dst.Set(list)
+			return nil
+		case reflect.Array:
+			if dst.Len() != len(s) {
+				return fmt.Errorf("cannot scan list of length %d into %s", len(s), dst.Type())
+			}
+			for i, elem := range s {
+				if err := assignValue(dst.Index(i), elem); err != nil {
+					return fmt.Errorf("element %d: %w", i, err)
+				}
+			}
+			return nil
+		}
+	}
+	return convertAssign(dst.Addr().Interface(), src)
+}
+
+func assignMap(dst reflect.Value, size int, each func(set func(k, v any) error) error) error {
+	m := reflect.MakeMapWithSize(dst.Type(), size)
+	err := each(func(k, v any) error {
+		key := reflect.New(dst.Type().Key()).Elem()
+		if err := assignValue(key, k); err != nil {
+			return err
+		}
+		value := reflect.New(dst.Type().Elem()).Elem()
+		if err := assignValue(value, v); err != nil {
+			return err
+		}
+		m.SetMapIndex(key, value)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	dst.Set(m)
+	return nil
+}
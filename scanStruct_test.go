@@ -0,0 +1,115 @@
+package duckdbcapi
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type scanAddress struct {
+	City string
+	Zip  *int
+}
+
+type scanBase struct {
+	ID int64 `duckdb:"id"`
+}
+
+type scanPerson struct {
+	scanBase
+	FullName string `duckdb:"name"`
+	Age      sql.NullInt32
+	Born     time.Time
+	Tags     []string
+	Address  scanAddress `duckdb:"addr"`
+	Scores   map[string]int
+	Ignored  string `duckdb:"-"`
+}
+
+func TestStructPlanPromotion(t *testing.T) {
+	type inner struct {
+		Name  string
+		Code  string `duckdb:"code"`
+		Left  int
+		Right int
+	}
+	type other struct {
+		Left int
+	}
+	type outer struct {
+		inner
+		other
+		Name  string
+		Right int `duckdb:"right"`
+	}
+	plan := structPlanFor(reflect.TypeOf(outer{}))
+	// the outer field shadows the embedded one declared before it
+	assert.Equal(t, []int{2}, plan.lookup("name"))
+	assert.Equal(t, []int{3}, plan.lookup("right"))
+	assert.Equal(t, []int{0, 1}, plan.lookup("code"))
+	// Left is promoted from two structs at the same depth
+	assert.Nil(t, plan.lookup("left"))
+}
+
+func TestScanStructsInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+
+	assert.Nil(t, tester.NoResultQuery(`CREATE TABLE people (id BIGINT, name VARCHAR, AGE INTEGER,
+		born DATE, tags VARCHAR[], addr STRUCT(city VARCHAR, zip INTEGER), scores MAP(VARCHAR, INTEGER),
+		ignored VARCHAR, extra DOUBLE)`))
+	assert.Nil(t, tester.NoResultQuery(`INSERT INTO people VALUES
+		(1, 'Alice', 30, DATE '1992-09-03', ['a', 'b'], {'city': 'Paris', 'zip': 75001}, map(['x'], [1]), 'skip', 1.5),
+		(2, 'Bob', NULL, DATE '2000-01-01', [], {'city': 'Oslo', 'zip': NULL}, NULL, 'skip', 2.5)`))
+
+	var result Result
+	assert.Nil(t, tester.conn.Query("SELECT * FROM people ORDER BY id", &result))
+	people, err := ScanStructs[scanPerson](&result)
+	result.Destroy()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(people))
+
+	zip := 75001
+	assert.Equal(t, scanPerson{
+		scanBase: scanBase{ID: 1},
+		FullName: "Alice",
+		Age:      sql.NullInt32{Int32: 30, Valid: true},
+		Born:     time.Date(1992, 9, 3, 0, 0, 0, 0, time.UTC),
+		Tags:     []string{"a", "b"},
+		Address:  scanAddress{City: "Paris", Zip: &zip},
+		Scores:   map[string]int{"x": 1},
+	}, people[0])
+	assert.Equal(t, int64(2), people[1].ID)
+	assert.Equal(t, false, people[1].Age.Valid)
+	assert.Equal(t, []string{}, people[1].Tags)
+	assert.Equal(t, "Oslo", people[1].Address.City)
+	assert.Nil(t, people[1].Address.Zip)
+	assert.Nil(t, people[1].Scores)
+	assert.Equal(t, "", people[1].Ignored)
+
+	assert.Nil(t, tester.conn.Query("SELECT name, id FROM people WHERE id = 2", &result))
+	person, err := ScanStruct[scanPerson](&result)
+	result.Destroy()
+	assert.Nil(t, err)
+	assert.Equal(t, "Bob", person.FullName)
+	assert.Equal(t, int64(2), person.ID)
+
+	assert.Nil(t, tester.conn.Query("SELECT * FROM people WHERE id = 3", &result))
+	_, err = ScanStruct[scanPerson](&result)
+	result.Destroy()
+	assert.ErrorIs(t, err, ErrRowsNoRow)
+
+	assert.Nil(t, tester.conn.Query("SELECT NULL::VARCHAR AS name, 1 AS id", &result))
+	_, err = ScanStruct[struct{ Name string }](&result)
+	result.Destroy()
+	assert.Error(t, err)
+
+	assert.Nil(t, tester.conn.Query("SELECT 1", &result))
+	_, err = ScanStructs[int](&result)
+	result.Destroy()
+	assert.ErrorIs(t, err, ErrScanStructType)
+}
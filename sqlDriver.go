@@ -0,0 +1,345 @@
+package duckdbcapi
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const DriverName = "duckdb-capi"
+
+var (
+	ErrDriverConnClosed     = errors.New("ErrDriverConnClosed")
+	ErrDriverStmtClosed     = errors.New("ErrDriverStmtClosed")
+	ErrDriverLastInsertId   = errors.New("ErrDriverLastInsertId")
+	ErrDriverTxOptions      = errors.New("ErrDriverTxOptions")
+	ErrDriverUnsupportedArg = errors.New("ErrDriverUnsupportedArg")
+)
+
+func init() {
+	sql.Register(DriverName, &Driver{})
+}
+
+// Driver implements database/sql/driver.Driver and driver.DriverContext.
+// The DSN is a database path optionally followed by config options,
+// e.g. "/tmp/test.db?threads=4&access_mode=READ_ONLY". An empty path
+// or ":memory:" opens an in-memory database.
+type Driver struct{}
+
+func (d *Driver) Open(dsn string) (driver.Conn, error) {
+	connector, err := NewConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	c, err := connector.Connect(context.Background())
+	if err != nil {
+		connector.Close()
+		return nil, err
+	}
+	// without a connector the connection owns the database
+	c.(*sqlConn).db = connector.db
+	return c, nil
+}
+
+func (d *Driver) OpenConnector(dsn string) (driver.Connector, error) {
+	return NewConnector(dsn)
+}
+
+func parseDSN(dsn string) (string, url.Values, error) {
+	path, rawQuery, _ := strings.Cut(dsn, "?")
+	options, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", nil, err
+	}
+	return path, options, nil
+}
+
+// Connector shares one DataBase between all connections of a sql.DB,
+// which keeps in-memory databases visible to every pooled connection.
+type Connector struct {
+	db *DataBase
+}
+
+func NewConnector(dsn string) (*Connector, error) {
+	path, options, err := parseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := CreateConfig()
+	if err != nil {
+		return nil, err
+	}
+	defer cfg.Destroy()
+	for name, values := range options {
+		for _, value := range values {
+			if err := cfg.SetConfig(name, value); err != nil {
+				return nil, fmt.Errorf("invalid config option %s=%s: %w", name, value, err)
+			}
+		}
+	}
+	db, _, err := OpenExt(path, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Connector{db: db}, nil
+}
+
+func (c *Connector) Connect(context.Context) (driver.Conn, error) {
+	conn, err := c.db.Connection()
+	if err != nil {
+		return nil, err
+	}
+	return &sqlConn{conn: conn}, nil
+}
+
+func (c *Connector) Driver() driver.Driver {
+	return &Driver{}
+}
+
+func (c *Connector) Close() error {
+	c.db.Close()
+	return nil
+}
+
+type sqlConn struct {
+	db     *DataBase
+	conn   *Connection
+	closed bool
+}
+
+func (c *sqlConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *sqlConn) PrepareContext(_ context.Context, query string) (driver.Stmt, error) {
+	if c.closed {
+		return nil, ErrDriverConnClosed
+	}
+	s := &sqlStmt{c: c}
+	if err := c.conn.Prepare(query, &s.stmt); err != nil {
+		s.stmt.Destroy()
+		// $name parameters for sql.Named are only rewritten when DuckDB
+		// rejects the query as is
+		if _, names, rewriteErr := rewriteNamedParams(query); rewriteErr != nil || names == nil {
+			return nil, err
+		}
+		if err := c.conn.PrepareNamed(query, &s.stmt); err != nil {
+			s.stmt.Destroy()
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// ExecContext runs argument-less queries directly so multi-statement
+// scripts (e.g. migrations) work; everything else goes through Prepare.
+func (c *sqlConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if c.closed {
+		return nil, ErrDriverConnClosed
+	}
+	if len(args) != 0 {
+		return nil, driver.ErrSkip
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var res Result
+	defer res.Destroy()
+	if err := c.conn.Query(query, &res); err != nil {
+		return nil, err
+	}
+	return sqlResult{rowsChanged: int64(res.RowsChanged())}, nil
+}
+
+func (c *sqlConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *sqlConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if c.closed {
+		return nil, ErrDriverConnClosed
+	}
+	if opts.ReadOnly || sql.IsolationLevel(opts.Isolation) != sql.LevelDefault {
+		return nil, ErrDriverTxOptions
+	}
+	if _, err := c.ExecContext(ctx, "BEGIN TRANSACTION", nil); err != nil {
+		return nil, err
+	}
+	return &sqlTx{c: c}, nil
+}
+
+func (c *sqlConn) Close() error {
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	c.conn.Disconnect()
+	if c.db != nil {
+		c.db.Close()
+	}
+	return nil
+}
+
+// CheckNamedValue passes the argument types PreparedStatement.BindAt binds
+// natively, which the default converter rejects or turns into other types:
+// uint64 above MaxInt64, *big.Int, time.Duration and the package's value
+// types. Everything else goes through the default converter.
+func (c *sqlConn) CheckNamedValue(nv *driver.NamedValue) error {
+	switch nv.Value.(type) {
+	case uint, uint64, time.Duration, *big.Int, HugeInt, Decimal, UUID,
+		Date, Time, Timestamp, Interval, Float, Double:
+		return nil
+	}
+	return driver.ErrSkip
+}
+
+type sqlTx struct {
+	c *sqlConn
+}
+
+func (t *sqlTx) Commit() error {
+	_, err := t.c.ExecContext(context.Background(), "COMMIT", nil)
+	return err
+}
+
+func (t *sqlTx) Rollback() error {
+	_, err := t.c.ExecContext(context.Background(), "ROLLBACK", nil)
+	return err
+}
+
+type sqlStmt struct {
+	c      *sqlConn
+	stmt   PreparedStatement
+	closed bool
+}
+
+func (s *sqlStmt) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	s.stmt.Destroy()
+	return nil
+}
+
+func (s *sqlStmt) NumInput() int {
+	return int(s.stmt.NParams())
+}
+
+func (s *sqlStmt) bind(args []driver.NamedValue) error {
+	for _, arg := range args {
+		idx := uint64(arg.Ordinal)
+		if arg.Name != "" {
+			var err error
+			if idx, err = s.stmt.ParamIndex(arg.Name); err != nil {
+				return err
+			}
+		}
+		if err := s.stmt.BindAt(idx, arg.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *sqlStmt) execute(ctx context.Context, args []driver.NamedValue, res *Result) error {
+	if s.closed {
+		return ErrDriverStmtClosed
+	}
+	if s.c.closed {
+		return ErrDriverConnClosed
+	}
+	if err := s.bind(args); err != nil {
+		return err
+	}
+	return s.stmt.ExecuteContext(ctx, res)
+}
+
+func (s *sqlStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), namedValues(args))
+}
+
+func (s *sqlStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	var res Result
+	defer res.Destroy()
+	if err := s.execute(ctx, args, &res); err != nil {
+		return nil, err
+	}
+	return sqlResult{rowsChanged: int64(res.RowsChanged())}, nil
+}
+
+func (s *sqlStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), namedValues(args))
+}
+
+func (s *sqlStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	r := &sqlRows{}
+	if err := s.execute(ctx, args, &r.res); err != nil {
+		r.res.Destroy()
+		return nil, err
+	}
+	return r, nil
+}
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+	return named
+}
+
+type sqlResult struct {
+	rowsChanged int64
+}
+
+func (r sqlResult) LastInsertId() (int64, error) {
+	return 0, ErrDriverLastInsertId
+}
+
+func (r sqlResult) RowsAffected() (int64, error) {
+	return r.rowsChanged, nil
+}
+
+type sqlRows struct {
+	res     Result
+	row     uint64
+	columns []string
+}
+
+func (r *sqlRows) Columns() []string {
+	if r.columns == nil {
+		r.columns = make([]string, r.res.ColumnCount())
+		for i := range r.columns {
+			r.columns[i], _ = r.res.ColumnName(uint64(i))
+		}
+	}
+	return r.columns
+}
+
+func (r *sqlRows) Close() error {
+	r.res.Destroy()
+	return nil
+}
+
+func (r *sqlRows) Next(dest []driver.Value) error {
+	if r.row >= r.res.RowCount() {
+		return io.EOF
+	}
+	for i := range dest {
+		dest[i] = driverValue(resultValue(&r.res, uint64(i), r.row))
+	}
+	r.row++
+	return nil
+}
+
+func (r *sqlRows) ColumnTypeDatabaseTypeName(index int) string {
+	return typeName(r.res.ColumnType(uint64(index)))
+}
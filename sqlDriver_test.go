@@ -0,0 +1,183 @@
+package duckdbcapi
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLDriver(t *testing.T) {
+	db, err := sql.Open(DriverName, "")
+	assert.Nil(t, err)
+	defer db.Close()
+	assert.Nil(t, db.Ping())
+
+	_, err = db.Exec("CREATE TABLE people (id INTEGER, name VARCHAR, born DATE, score DOUBLE); CREATE TABLE empty (i INTEGER)")
+	assert.Nil(t, err)
+
+	res, err := db.Exec("INSERT INTO people VALUES (?, ?, ?, ?), (?, ?, ?, ?)",
+		1, "Alice", time.Date(1992, 9, 3, 0, 0, 0, 0, time.UTC), 0.5,
+		2, "Bob", nil, nil)
+	assert.Nil(t, err)
+	affected, err := res.RowsAffected()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), affected)
+	_, err = res.LastInsertId()
+	assert.Equal(t, ErrDriverLastInsertId, err)
+
+	t.Run("query rows", func(t *testing.T) {
+		rows, err := db.Query("SELECT id, name, born, score FROM people ORDER BY id")
+		assert.Nil(t, err)
+		defer rows.Close()
+		columns, err := rows.Columns()
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"id", "name", "born", "score"}, columns)
+		types, err := rows.ColumnTypes()
+		assert.Nil(t, err)
+		assert.Equal(t, "INTEGER", types[0].DatabaseTypeName())
+		assert.Equal(t, "VARCHAR", types[1].DatabaseTypeName())
+
+		var id int
+		var name string
+		var born sql.NullTime
+		var score sql.NullFloat64
+		assert.Equal(t, true, rows.Next())
+		assert.Nil(t, rows.Scan(&id, &name, &born, &score))
+		assert.Equal(t, 1, id)
+		assert.Equal(t, "Alice", name)
+		assert.Equal(t, true, born.Valid)
+		assert.Equal(t, time.Date(1992, 9, 3, 0, 0, 0, 0, time.UTC), born.Time)
+		assert.Equal(t, 0.5, score.Float64)
+
+		assert.Equal(t, true, rows.Next())
+		assert.Nil(t, rows.Scan(&id, &name, &born, &score))
+		assert.Equal(t, 2, id)
+		assert.Equal(t, "Bob", name)
+		assert.Equal(t, false, born.Valid)
+		assert.Equal(t, false, score.Valid)
+
+		assert.Equal(t, false, rows.Next())
+		assert.Nil(t, rows.Err())
+	})
+
+	t.Run("prepared statement", func(t *testing.T) {
+		stmt, err := db.Prepare("SELECT name FROM people WHERE id = $1")
+		assert.Nil(t, err)
+		defer stmt.Close()
+		var name string
+		assert.Nil(t, stmt.QueryRow(2).Scan(&name))
+		assert.Equal(t, "Bob", name)
+		assert.Equal(t, sql.ErrNoRows, stmt.QueryRow(3).Scan(&name))
+		_, err = stmt.Exec(1, 2)
+		assert.Error(t, err)
+	})
+
+	t.Run("transactions", func(t *testing.T) {
+		ctx := context.Background()
+		conn, err := db.Conn(ctx)
+		assert.Nil(t, err)
+		defer conn.Close()
+
+		tx, err := conn.BeginTx(ctx, nil)
+		assert.Nil(t, err)
+		_, err = tx.Exec("INSERT INTO empty VALUES (1)")
+		assert.Nil(t, err)
+		assert.Nil(t, tx.Rollback())
+
+		tx, err = conn.BeginTx(ctx, nil)
+		assert.Nil(t, err)
+		_, err = tx.Exec("INSERT INTO empty VALUES (2)")
+		assert.Nil(t, err)
+		assert.Nil(t, tx.Commit())
+
+		var total int64
+		assert.Nil(t, conn.QueryRowContext(ctx, "SELECT SUM(i) FROM empty").Scan(&total))
+		assert.Equal(t, int64(2), total)
+
+		_, err = conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+		assert.Equal(t, ErrDriverTxOptions, err)
+	})
+
+	t.Run("scan types", func(t *testing.T) {
+		row := db.QueryRow(`SELECT 7::TINYINT, 300::USMALLINT, 18446744073709551615::UBIGINT,
+			170141183460469231731687303715884105727::HUGEINT, 42::HUGEINT, 1.5::FLOAT,
+			INTERVAL 90 SECONDS, INTERVAL 1 MONTH`)
+		var (
+			tiny      int8
+			small     string
+			ubig      uint64
+			huge      string
+			hugeSmall int64
+			f         float32
+			seconds   time.Duration
+			month     string
+		)
+		assert.Nil(t, row.Scan(&tiny, &small, &ubig, &huge, &hugeSmall, &f, &seconds, &month))
+		assert.Equal(t, int8(7), tiny)
+		assert.Equal(t, "300", small)
+		assert.Equal(t, uint64(18446744073709551615), ubig)
+		assert.Equal(t, "170141183460469231731687303715884105727", huge)
+		assert.Equal(t, int64(42), hugeSmall)
+		assert.Equal(t, float32(1.5), f)
+		assert.Equal(t, 90*time.Second, seconds)
+		assert.Equal(t, "1 months 0 days 0 microseconds", month)
+
+		var v any
+		assert.Nil(t, db.QueryRow("SELECT 1::INTEGER").Scan(&v))
+		assert.Equal(t, int64(1), v)
+	})
+
+	t.Run("bind types", func(t *testing.T) {
+		uid, err := ParseUUID("a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11")
+		assert.Nil(t, err)
+		price, err := ParseDecimal("12.34", 4, 2)
+		assert.Nil(t, err)
+		var (
+			gotUUID, gotBig, gotPrice string
+			gotInterval               time.Duration
+		)
+		row := db.QueryRow("SELECT ?::UUID::VARCHAR, ?::UBIGINT::VARCHAR, ?::DECIMAL(4,2)::VARCHAR, ?::INTERVAL",
+			uid, uint64(18446744073709551615), price, 90*time.Second)
+		assert.Nil(t, row.Scan(&gotUUID, &gotBig, &gotPrice, &gotInterval))
+		assert.Equal(t, uid.String(), gotUUID)
+		assert.Equal(t, "18446744073709551615", gotBig)
+		assert.Equal(t, "12.34", gotPrice)
+		assert.Equal(t, 90*time.Second, gotInterval)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := db.Exec("SELEC * FROM people")
+		assert.Error(t, err)
+		_, err = db.Query("SELECT * FROM nonexistent WHERE i = ?", 1)
+		assert.Error(t, err)
+		_, err = db.Exec("INSERT INTO empty VALUES (?)", struct{}{})
+		assert.Error(t, err)
+	})
+}
+
+func TestSQLDriverDSN(t *testing.T) {
+	path, options, err := parseDSN("/tmp/test.db?threads=4&access_mode=READ_ONLY")
+	assert.Nil(t, err)
+	assert.Equal(t, "/tmp/test.db", path)
+	assert.Equal(t, "4", options.Get("threads"))
+	assert.Equal(t, "READ_ONLY", options.Get("access_mode"))
+
+	path, options, err = parseDSN(":memory:")
+	assert.Nil(t, err)
+	assert.Equal(t, ":memory:", path)
+	assert.Equal(t, 0, len(options))
+
+	db, err := sql.Open(DriverName, ":memory:?threads=1")
+	assert.Nil(t, err)
+	assert.Nil(t, db.Ping())
+	var threads string
+	assert.Nil(t, db.QueryRow("SELECT current_setting('threads')").Scan(&threads))
+	assert.Equal(t, "1", threads)
+	assert.Nil(t, db.Close())
+
+	_, err = sql.Open(DriverName, "?aaaa_invalidoption=1")
+	assert.Error(t, err)
+}
@@ -60,11 +60,12 @@ func goBridgeDeleteTableFunctionInitData(data unsafe.Pointer) {
 }
 
 type TableFunction struct {
-	c C.duckdb_table_function
+	c    C.duckdb_table_function
+	name string
 }
 
 func CreateTableFunction() *TableFunction {
-	return &TableFunction{C.duckdb_create_table_function()}
+	return &TableFunction{c: C.duckdb_create_table_function()}
 }
 
 func (t *TableFunction) Destroy() {
@@ -72,6 +73,7 @@ func (t *TableFunction) Destroy() {
 }
 
 func (t *TableFunction) SetName(name string) {
+	t.name = name
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 	C.duckdb_table_function_set_name(t.c, cName)
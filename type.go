@@ -260,3 +260,66 @@ func (i *Interval) Days() int32 {
 func (i *Interval) Micros() int64 {
 	return int64(i.c.micros)
 }
+
+func typeName(t Type) string {
+	switch t {
+	case DuckDBTypeBoolean:
+		return "BOOLEAN"
+	case DuckDBTypeTinyInt:
+		return "TINYINT"
+	case DuckDBTypeSmallInt:
+		return "SMALLINT"
+	case DuckDBTypeInteger:
+		return "INTEGER"
+	case DuckDBTypeBigInt:
+		return "BIGINT"
+	case DuckDBTypeUTinyInt:
+		return "UTINYINT"
+	case DuckDBTypeUSmallInt:
+		return "USMALLINT"
+	case DuckDBTypeUInteger:
+		return "UINTEGER"
+	case DuckDBTypeUBigInt:
+		return "UBIGINT"
+	case DuckDBTypeFloat:
+		return "FLOAT"
+	case DuckDBTypeDouble:
+		return "DOUBLE"
+	case DuckDBTypeTimestamp:
+		return "TIMESTAMP"
+	case DuckDBTypeDate:
+		return "DATE"
+	case DuckDBTypeTime:
+		return "TIME"
+	case DuckDBTypeInterval:
+		return "INTERVAL"
+	case DuckDBTypeHugeInt:
+		return "HUGEINT"
+	case DuckDBTypeVarChar:
+		return "VARCHAR"
+	case DuckDBTypeBlob:
+		return "BLOB"
+	case DuckDBTypeDecimal:
+		return "DECIMAL"
+	case DuckDBTypeTimestamp_S:
+		return "TIMESTAMP_S"
+	case DuckDBTypeTimestamp_MS:
+		return "TIMESTAMP_MS"
+	case DuckDBTypeTimestamp_NS:
+		return "TIMESTAMP_NS"
+	case DuckDBTypeEnum:
+		return "ENUM"
+	case DuckDBTypeList:
+		return "LIST"
+	case DuckDBTypeStruct:
+		return "STRUCT"
+	case DuckDBTypeMap:
+		return "MAP"
+	case DuckDBTypeUUID:
+		return "UUID"
+	case DuckDBTypeJson:
+		return "JSON"
+	default:
+		return "INVALID"
+	}
+}
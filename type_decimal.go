@@ -0,0 +1,62 @@
+package duckdbcapi
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var (
+	ErrDecimalOverflow = errors.New("ErrDecimalOverflow")
+	ErrDecimalSyntax   = errors.New("ErrDecimalSyntax")
+)
+
+// String renders the decimal with exactly Scale() fractional digits.
+func (d *Decimal) String() string {
+	return formatDecimal(hugeIntToBig(d.Value()), d.Scale())
+}
+
+// Rat returns the exact value of d.
+func (d *Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(hugeIntToBig(d.Value()), pow10(d.Scale()))
+}
+
+// Rescale converts d to DECIMAL(width,scale), rounding half away from zero
+// when the scale shrinks.
+func (d *Decimal) Rescale(width, scale uint8) (Decimal, error) {
+	return DecimalFromRat(d.Rat(), width, scale)
+}
+
+// ParseDecimal parses s, e.g. "-12.345" or "1e3", as DECIMAL(width,scale),
+// rounding half away from zero to scale fractional digits.
+func ParseDecimal(s string, width, scale uint8) (Decimal, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok || strings.Contains(s, "/") {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrDecimalSyntax, s)
+	}
+	return DecimalFromRat(r, width, scale)
+}
+
+// DecimalFromRat rounds r half away from zero to DECIMAL(width,scale). It
+// fails with ErrDecimalOverflow when the result needs more than width
+// digits.
+func DecimalFromRat(r *big.Rat, width, scale uint8) (Decimal, error) {
+	if width < 1 || width > 38 || scale > width {
+		return Decimal{}, fmt.Errorf("%w: invalid DECIMAL(%d,%d)", ErrDecimalOverflow, width, scale)
+	}
+	num := new(big.Int).Mul(r.Num(), pow10(scale))
+	unscaled, rem := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+	if rem.Sign() != 0 && new(big.Int).Abs(new(big.Int).Lsh(rem, 1)).Cmp(r.Denom()) >= 0 {
+		unscaled.Add(unscaled, big.NewInt(int64(num.Sign())))
+	}
+	if new(big.Int).Abs(unscaled).Cmp(pow10(width)) >= 0 {
+		return Decimal{}, fmt.Errorf("%w: %s does not fit DECIMAL(%d,%d)", ErrDecimalOverflow, r.FloatString(int(scale)), width, scale)
+	}
+	h, _ := bigToHugeInt(unscaled)
+	return InitDecimal(width, scale, h), nil
+}
+
+func pow10(n uint8) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
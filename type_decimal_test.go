@@ -0,0 +1,91 @@
+package duckdbcapi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecimal(t *testing.T) {
+	d, err := ParseDecimal("-12.345", 9, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, "-12.35", d.String())
+	assert.Equal(t, uint8(9), d.Width())
+	assert.Equal(t, uint8(2), d.Scale())
+	assert.Equal(t, 0, d.Rat().Cmp(big.NewRat(-1235, 100)))
+
+	d, err = ParseDecimal("0.005", 3, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, "0.005", d.String())
+	rescaled, err := d.Rescale(4, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, "0.01", rescaled.String())
+
+	d, err = ParseDecimal("1e3", 4, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "1000", d.String())
+	_, err = ParseDecimal("1e4", 4, 0)
+	assert.ErrorIs(t, err, ErrDecimalOverflow)
+	_, err = ParseDecimal("1/3", 4, 2)
+	assert.ErrorIs(t, err, ErrDecimalSyntax)
+	_, err = ParseDecimal("abc", 4, 2)
+	assert.ErrorIs(t, err, ErrDecimalSyntax)
+	_, err = ParseDecimal("1", 39, 2)
+	assert.ErrorIs(t, err, ErrDecimalOverflow)
+
+	d, err = ParseDecimal("12345678901234567890123456789012345678", 38, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "12345678901234567890123456789012345678", d.String())
+
+	d, err = DecimalFromRat(big.NewRat(1, 3), 5, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, "0.3333", d.String())
+}
+
+func TestDecimalInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+	assert.Nil(t, tester.NoResultQuery("CREATE TABLE money (a DECIMAL(4,1), b DECIMAL(9,2), c DECIMAL(18,3), d DECIMAL(38,4))"))
+
+	var stmt PreparedStatement
+	assert.Nil(t, tester.conn.Prepare("INSERT INTO money VALUES ($1, $2, $3, $4)", &stmt))
+	defer stmt.Destroy()
+	values := []string{"-123.4", "1234567.89", "-123456789012345.678", "1234567890123456789012345678901234.5678"}
+	widths := [][2]uint8{{4, 1}, {9, 2}, {18, 3}, {38, 4}}
+	for i, v := range values {
+		d, err := ParseDecimal(v, widths[i][0], widths[i][1])
+		assert.Nil(t, err)
+		assert.Nil(t, stmt.BindDecimal(uint64(i+1), d))
+	}
+	assert.Nil(t, stmt.ExecutePrepared(nil))
+
+	appender, err := tester.conn.AppenderCreate("", "money")
+	assert.Nil(t, err)
+	assert.Nil(t, appender.BeginRow())
+	for i, v := range values {
+		d, err := ParseDecimal(v, widths[i][0], widths[i][1])
+		assert.Nil(t, err)
+		assert.Nil(t, appender.AppendDecimal(d))
+	}
+	assert.Nil(t, appender.EndRow())
+	assert.Nil(t, appender.Destroy())
+
+	var result Result
+	assert.Nil(t, tester.conn.Query("SELECT * FROM money", &result))
+	defer result.Destroy()
+	chunk, err := result.Chunk(0)
+	assert.Nil(t, err)
+	defer chunk.Destroy()
+	for col := range values {
+		vec, err := chunk.GetVector(uint64(col))
+		assert.Nil(t, err)
+		decimals, valid, err := vec.Decimals(chunk.GetSize())
+		assert.Nil(t, err)
+		assert.Equal(t, []bool{true, true}, valid)
+		assert.Equal(t, values[col], decimals[0].String())
+		assert.Equal(t, values[col], decimals[1].String())
+		assert.Equal(t, widths[col][0], decimals[0].Width())
+	}
+}
@@ -0,0 +1,120 @@
+package duckdbcapi
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+var (
+	ErrHugeIntOverflow = errors.New("ErrHugeIntOverflow")
+	ErrHugeIntSyntax   = errors.New("ErrHugeIntSyntax")
+)
+
+func hugeIntToBig(h HugeInt) *big.Int {
+	v := new(big.Int).SetInt64(h.Upper())
+	v.Lsh(v, 64)
+	return v.Add(v, new(big.Int).SetUint64(h.Lower()))
+}
+
+// bigToHugeInt accepts the HUGEINT range of DuckDB, which excludes -2^127.
+func bigToHugeInt(v *big.Int) (HugeInt, bool) {
+	if v.BitLen() > 127 {
+		return HugeInt{}, false
+	}
+	lower := new(big.Int).And(v, new(big.Int).SetUint64(math.MaxUint64)).Uint64()
+	upper := new(big.Int).Rsh(v, 64).Int64()
+	return InitHugInt(lower, upper), true
+}
+
+func hugeIntFromInt64(v int64) HugeInt {
+	return InitHugInt(uint64(v), v>>63)
+}
+
+// BigInt returns the exact value of h.
+func (h *HugeInt) BigInt() *big.Int {
+	return hugeIntToBig(*h)
+}
+
+// HugeIntFromBig fails with ErrHugeIntOverflow when v is outside the
+// HUGEINT range [-2^127+1, 2^127-1]; like DuckDB, -2^127 is not a valid
+// HUGEINT.
+func HugeIntFromBig(v *big.Int) (HugeInt, error) {
+	h, ok := bigToHugeInt(v)
+	if !ok {
+		return HugeInt{}, fmt.Errorf("%w: %s", ErrHugeIntOverflow, v)
+	}
+	return h, nil
+}
+
+func (h *HugeInt) String() string {
+	return h.BigInt().String()
+}
+
+func ParseHugeInt(s string) (HugeInt, error) {
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return HugeInt{}, fmt.Errorf("%w: %q", ErrHugeIntSyntax, s)
+	}
+	return HugeIntFromBig(v)
+}
+
+// Cmp returns -1, 0 or +1 depending on whether h is less than, equal to or
+// greater than other.
+func (h *HugeInt) Cmp(other HugeInt) int {
+	switch {
+	case h.Upper() < other.Upper():
+		return -1
+	case h.Upper() > other.Upper():
+		return 1
+	case h.Lower() < other.Lower():
+		return -1
+	case h.Lower() > other.Lower():
+		return 1
+	}
+	return 0
+}
+
+// isHugeIntMin reports -2^127, which fits the two's complement layout but
+// is outside DuckDB's HUGEINT range.
+func isHugeIntMin(upper int64, lower uint64) bool {
+	return upper == math.MinInt64 && lower == 0
+}
+
+// Add, Sub, Mul and Neg fail with ErrHugeIntOverflow instead of wrapping
+// around, like the HUGEINT operators in DuckDB.
+func (h *HugeInt) Add(other HugeInt) (HugeInt, error) {
+	lower, carry := bits.Add64(h.Lower(), other.Lower(), 0)
+	upper, _ := bits.Add64(uint64(h.Upper()), uint64(other.Upper()), carry)
+	if (h.Upper() < 0) == (other.Upper() < 0) && (int64(upper) < 0) != (h.Upper() < 0) || isHugeIntMin(int64(upper), lower) {
+		return HugeInt{}, fmt.Errorf("%w: %s + %s", ErrHugeIntOverflow, h, &other)
+	}
+	return InitHugInt(lower, int64(upper)), nil
+}
+
+func (h *HugeInt) Sub(other HugeInt) (HugeInt, error) {
+	lower, borrow := bits.Sub64(h.Lower(), other.Lower(), 0)
+	upper, _ := bits.Sub64(uint64(h.Upper()), uint64(other.Upper()), borrow)
+	if (h.Upper() < 0) != (other.Upper() < 0) && (int64(upper) < 0) != (h.Upper() < 0) || isHugeIntMin(int64(upper), lower) {
+		return HugeInt{}, fmt.Errorf("%w: %s - %s", ErrHugeIntOverflow, h, &other)
+	}
+	return InitHugInt(lower, int64(upper)), nil
+}
+
+func (h *HugeInt) Mul(other HugeInt) (HugeInt, error) {
+	product, ok := bigToHugeInt(new(big.Int).Mul(h.BigInt(), other.BigInt()))
+	if !ok {
+		return HugeInt{}, fmt.Errorf("%w: %s * %s", ErrHugeIntOverflow, h, &other)
+	}
+	return product, nil
+}
+
+func (h *HugeInt) Neg() (HugeInt, error) {
+	if isHugeIntMin(h.Upper(), h.Lower()) {
+		return HugeInt{}, fmt.Errorf("%w: -(%s)", ErrHugeIntOverflow, h)
+	}
+	zero := InitHugInt(0, 0)
+	return zero.Sub(*h)
+}
@@ -0,0 +1,68 @@
+package duckdbcapi
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHugeInt(t *testing.T) {
+	max, err := ParseHugeInt("170141183460469231731687303715884105727")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), max.Lower())
+	assert.Equal(t, int64(math.MaxInt64), max.Upper())
+	min, err := ParseHugeInt("-170141183460469231731687303715884105727")
+	assert.Nil(t, err)
+	assert.Equal(t, "-170141183460469231731687303715884105727", min.String())
+	_, err = ParseHugeInt("170141183460469231731687303715884105728")
+	assert.ErrorIs(t, err, ErrHugeIntOverflow)
+	_, err = ParseHugeInt("-170141183460469231731687303715884105728")
+	assert.ErrorIs(t, err, ErrHugeIntOverflow)
+	_, err = ParseHugeInt("12a")
+	assert.ErrorIs(t, err, ErrHugeIntSyntax)
+
+	big53 := new(big.Int).Lsh(big.NewInt(1), 53)
+	big53.Add(big53, big.NewInt(1))
+	h, err := HugeIntFromBig(big53)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, big53.Cmp(h.BigInt()))
+
+	one := InitHugInt(1, 0)
+	minusOne := InitHugInt(math.MaxUint64, -1)
+	assert.Equal(t, -1, minusOne.Cmp(one))
+	assert.Equal(t, 1, max.Cmp(min))
+	assert.Equal(t, 0, one.Cmp(InitHugInt(1, 0)))
+
+	sum, err := minusOne.Add(one)
+	assert.Nil(t, err)
+	assert.Equal(t, "0", sum.String())
+	maxLower := InitHugInt(math.MaxUint64, 0)
+	carry, err := maxLower.Add(one)
+	assert.Nil(t, err)
+	assert.Equal(t, "18446744073709551616", carry.String())
+	_, err = max.Add(one)
+	assert.ErrorIs(t, err, ErrHugeIntOverflow)
+	_, err = min.Sub(one)
+	assert.ErrorIs(t, err, ErrHugeIntOverflow)
+	diff, err := min.Sub(minusOne)
+	assert.Nil(t, err)
+	assert.Equal(t, "-170141183460469231731687303715884105726", diff.String())
+	_, err = minusOne.Add(min)
+	assert.ErrorIs(t, err, ErrHugeIntOverflow)
+
+	high := InitHugInt(1<<63, 0)
+	product, err := high.Mul(InitHugInt(4, 0))
+	assert.Nil(t, err)
+	assert.Equal(t, "36893488147419103232", product.String())
+	_, err = max.Mul(InitHugInt(2, 0))
+	assert.ErrorIs(t, err, ErrHugeIntOverflow)
+
+	neg, err := max.Neg()
+	assert.Nil(t, err)
+	assert.Equal(t, "-170141183460469231731687303715884105727", neg.String())
+	assert.Equal(t, 0, neg.Cmp(min))
+	_, err = min.Mul(InitHugInt(math.MaxUint64, -1))
+	assert.Nil(t, err)
+}
@@ -0,0 +1,148 @@
+package duckdbcapi
+
+import (
+	"math"
+	"time"
+)
+
+// DuckDB stores 'infinity' and '-infinity' as the largest and the negated
+// largest value of the underlying integer. They convert to and from these
+// time.Time values.
+var (
+	InfinityTime         = time.UnixMicro(math.MaxInt64).UTC()
+	NegativeInfinityTime = time.UnixMicro(-math.MaxInt64).UTC()
+)
+
+const secondsPerDay = 24 * 60 * 60
+
+// Time returns the date as midnight UTC.
+func (d *Date) Time() time.Time {
+	switch days := d.Days(); days {
+	case math.MaxInt32:
+		return InfinityTime
+	case -math.MaxInt32:
+		return NegativeInfinityTime
+	default:
+		return time.Unix(int64(days)*secondsPerDay, 0).UTC()
+	}
+}
+
+func (d *Date) IsInfinite() bool {
+	return d.Days() == math.MaxInt32 || d.Days() == -math.MaxInt32
+}
+
+// DateFromTime returns the calendar date of t in its own location.
+func DateFromTime(t time.Time) Date {
+	switch {
+	case t.Equal(InfinityTime):
+		return InitDate(math.MaxInt32)
+	case t.Equal(NegativeInfinityTime):
+		return InitDate(-math.MaxInt32)
+	}
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return InitDate(int32(floorDiv(midnight.Unix(), secondsPerDay)))
+}
+
+// Duration returns the time of day as the duration since midnight.
+func (t *Time) Duration() time.Duration {
+	return time.Duration(t.Micros()) * time.Microsecond
+}
+
+func TimeFromDuration(d time.Duration) Time {
+	return InitTime(d.Microseconds())
+}
+
+// TimeFromTime returns the wall clock time of t in its own location.
+func TimeFromTime(t time.Time) Time {
+	return InitTime(int64(t.Hour()*3600+t.Minute()*60+t.Second())*1e6 + int64(t.Nanosecond()/1e3))
+}
+
+func (t *Timestamp) Time() time.Time {
+	return TimestampPrecisionTime(DuckDBTypeTimestamp, t.Micros())
+}
+
+func (t *Timestamp) IsInfinite() bool {
+	return t.Micros() == math.MaxInt64 || t.Micros() == -math.MaxInt64
+}
+
+func TimestampFromTime(t time.Time) Timestamp {
+	return InitTimestamp(TimestampPrecisionFromTime(DuckDBTypeTimestamp, t))
+}
+
+// TimestampPrecisionTime converts the raw value of a TIMESTAMP (micros),
+// TIMESTAMP_S, TIMESTAMP_MS or TIMESTAMP_NS column to a UTC time.Time.
+func TimestampPrecisionTime(typ Type, v int64) time.Time {
+	switch v {
+	case math.MaxInt64:
+		return InfinityTime
+	case -math.MaxInt64:
+		return NegativeInfinityTime
+	}
+	switch typ {
+	case DuckDBTypeTimestamp_S:
+		return time.Unix(v, 0).UTC()
+	case DuckDBTypeTimestamp_MS:
+		return time.UnixMilli(v).UTC()
+	case DuckDBTypeTimestamp_NS:
+		return time.Unix(0, v).UTC()
+	default:
+		return time.UnixMicro(v).UTC()
+	}
+}
+
+// TimestampPrecisionFromTime is the inverse of TimestampPrecisionTime,
+// truncating t to the precision of typ.
+func TimestampPrecisionFromTime(typ Type, t time.Time) int64 {
+	switch {
+	case t.Equal(InfinityTime):
+		return math.MaxInt64
+	case t.Equal(NegativeInfinityTime):
+		return -math.MaxInt64
+	}
+	switch typ {
+	case DuckDBTypeTimestamp_S:
+		return t.Unix()
+	case DuckDBTypeTimestamp_MS:
+		return t.UnixMilli()
+	case DuckDBTypeTimestamp_NS:
+		return t.UnixNano()
+	default:
+		return t.UnixMicro()
+	}
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+func IntervalFromDuration(d time.Duration) Interval {
+	return InitInterval(0, 0, d.Microseconds())
+}
+
+// Duration returns the interval as a time.Duration. It reports false when
+// the interval has months or days, whose length depends on the time they
+// are added to; use AddTo for those.
+func (i *Interval) Duration() (time.Duration, bool) {
+	if i.Months() != 0 || i.Days() != 0 {
+		return 0, false
+	}
+	return time.Duration(i.Micros()) * time.Microsecond, true
+}
+
+// AddTo adds the interval to t the way DuckDB does: months are added first
+// and clamp to the end of the month (Jan 31 + 1 month is Feb 28), then days
+// as calendar days and micros as elapsed time.
+func (i *Interval) AddTo(t time.Time) time.Time {
+	year, month, day := t.Date()
+	first := time.Date(year, month+time.Month(i.Months()), 1, 0, 0, 0, 0, time.UTC)
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	hour, minute, sec := t.Clock()
+	t = time.Date(first.Year(), first.Month(), day, hour, minute, sec, t.Nanosecond(), t.Location())
+	return t.AddDate(0, 0, int(i.Days())).Add(time.Duration(i.Micros()) * time.Microsecond)
+}
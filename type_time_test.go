@@ -0,0 +1,73 @@
+package duckdbcapi
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeConversions(t *testing.T) {
+	d := DateFromTime(time.Date(1969, 12, 31, 23, 0, 0, 0, time.FixedZone("", -5*3600)))
+	assert.Equal(t, int32(-1), d.Days())
+	assert.Equal(t, time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC), d.Time())
+
+	inf := DateFromTime(InfinityTime)
+	assert.Equal(t, int32(math.MaxInt32), inf.Days())
+	assert.Equal(t, true, inf.IsInfinite())
+	assert.Equal(t, InfinityTime, inf.Time())
+	ninf := InitDate(-math.MaxInt32)
+	assert.Equal(t, NegativeInfinityTime, ninf.Time())
+
+	tm := TimeFromTime(time.Date(2022, 5, 1, 12, 34, 56, 789000, time.UTC))
+	assert.Equal(t, int64(45296000789), tm.Micros())
+	assert.Equal(t, 12*time.Hour+34*time.Minute+56*time.Second+789*time.Microsecond, tm.Duration())
+	tm = TimeFromDuration(time.Second)
+	assert.Equal(t, int64(1000000), tm.Micros())
+
+	instant := time.Date(1992, 9, 3, 12, 22, 33, 123456789, time.UTC)
+	ts := TimestampFromTime(instant)
+	assert.Equal(t, instant.Truncate(time.Microsecond), ts.Time())
+	assert.Equal(t, false, ts.IsInfinite())
+	ts = TimestampFromTime(NegativeInfinityTime)
+	assert.Equal(t, true, ts.IsInfinite())
+	assert.Equal(t, int64(-math.MaxInt64), ts.Micros())
+
+	for typ, unit := range map[Type]time.Duration{
+		DuckDBTypeTimestamp_S:  time.Second,
+		DuckDBTypeTimestamp_MS: time.Millisecond,
+		DuckDBTypeTimestamp:    time.Microsecond,
+		DuckDBTypeTimestamp_NS: time.Nanosecond,
+	} {
+		raw := TimestampPrecisionFromTime(typ, instant)
+		assert.Equal(t, instant.Truncate(unit), TimestampPrecisionTime(typ, raw))
+		assert.Equal(t, InfinityTime, TimestampPrecisionTime(typ, math.MaxInt64))
+		assert.Equal(t, int64(math.MaxInt64), TimestampPrecisionFromTime(typ, InfinityTime))
+	}
+
+	interval := InitInterval(1, 1, int64(time.Hour/time.Microsecond))
+	_, ok := interval.Duration()
+	assert.Equal(t, false, ok)
+	assert.Equal(t, time.Date(2022, 3, 1, 1, 0, 0, 0, time.UTC), interval.AddTo(time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC)))
+	interval = IntervalFromDuration(90 * time.Second)
+	dur, ok := interval.Duration()
+	assert.Equal(t, true, ok)
+	assert.Equal(t, 90*time.Second, dur)
+}
+
+func TestInfinityTimestampsInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+
+	var result Result
+	assert.Nil(t, tester.conn.Query("SELECT 'infinity'::TIMESTAMP, '-infinity'::DATE", &result))
+	defer result.Destroy()
+	rows := result.Rows()
+	assert.Equal(t, true, rows.Next())
+	var ts, date time.Time
+	assert.Nil(t, rows.Scan(&ts, &date))
+	assert.Equal(t, InfinityTime, ts)
+	assert.Equal(t, NegativeInfinityTime, date)
+}
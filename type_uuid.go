@@ -0,0 +1,117 @@
+package duckdbcapi
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrUUIDSyntax = errors.New("ErrUUIDSyntax")
+
+// UUID is a UUID in its canonical big-endian byte order. DuckDB stores it
+// as a HugeInt whose top bit is flipped so that UUIDs sort like their
+// string form.
+type UUID [16]byte
+
+// UUIDFromHugeInt converts the storage form of a UUID column.
+func UUIDFromHugeInt(h HugeInt) UUID {
+	var u UUID
+	binary.BigEndian.PutUint64(u[:8], uint64(h.Upper())^(1<<63))
+	binary.BigEndian.PutUint64(u[8:], h.Lower())
+	return u
+}
+
+func (u UUID) HugeInt() HugeInt {
+	upper := binary.BigEndian.Uint64(u[:8]) ^ (1 << 63)
+	return InitHugInt(binary.BigEndian.Uint64(u[8:]), int64(upper))
+}
+
+func (u UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// ParseUUID accepts the forms DuckDB accepts: 32 hex digits, optionally
+// separated by hyphens and optionally wrapped in braces.
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	text := s
+	if strings.HasPrefix(text, "{") && strings.HasSuffix(text, "}") {
+		text = text[1 : len(text)-1]
+	}
+	text = strings.ReplaceAll(text, "-", "")
+	if len(text) != 32 {
+		return u, fmt.Errorf("%w: %q", ErrUUIDSyntax, s)
+	}
+	if _, err := hex.Decode(u[:], []byte(text)); err != nil {
+		return u, fmt.Errorf("%w: %q", ErrUUIDSyntax, s)
+	}
+	return u, nil
+}
+
+// Scan implements sql.Scanner for the string form DuckDB returns for UUID
+// columns, so a *UUID can be passed to Rows.Scan and database/sql.
+func (u *UUID) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		parsed, err := ParseUUID(v)
+		if err != nil {
+			return err
+		}
+		*u = parsed
+		return nil
+	case []byte:
+		if len(v) == len(u) {
+			copy(u[:], v)
+			return nil
+		}
+		return u.Scan(string(v))
+	case UUID:
+		*u = v
+		return nil
+	}
+	return fmt.Errorf("cannot scan %T into *UUID", src)
+}
+
+// Value implements driver.Valuer, binding the UUID in its string form.
+func (u UUID) Value() (driver.Value, error) {
+	return u.String(), nil
+}
+
+// ValueUUID reads a UUID column of a materialized result.
+func (r *Result) ValueUUID(col, row uint64) (UUID, error) {
+	return ParseUUID(r.ValueVarChar(col, row))
+}
+
+// UUIDs decodes the first size rows of a UUID vector.
+func (v *Vector) UUIDs(size uint64) ([]UUID, []bool, error) {
+	lt := v.GetColumnType()
+	typ := lt.GetTypeId()
+	lt.Destroy()
+	if typ != DuckDBTypeUUID {
+		return nil, nil, fmt.Errorf("%w: cannot read %s vector as UUIDs", ErrVectorTypeMismatch, typeName(typ))
+	}
+	hugeInts, valid, err := Column[HugeInt](v, size)
+	if err != nil {
+		return nil, nil, err
+	}
+	values := make([]UUID, size)
+	for i, h := range hugeInts {
+		if valid[i] {
+			values[i] = UUIDFromHugeInt(h)
+		}
+	}
+	return values, valid, nil
+}
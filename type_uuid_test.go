@@ -0,0 +1,89 @@
+package duckdbcapi
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUUID(t *testing.T) {
+	u, err := ParseUUID("a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11")
+	assert.Nil(t, err)
+	assert.Equal(t, "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", u.String())
+	braces, err := ParseUUID("{a0eebc999c0b4ef8bb6d6bb9bd380a11}")
+	assert.Nil(t, err)
+	assert.Equal(t, u, braces)
+	_, err = ParseUUID("a0eebc99")
+	assert.ErrorIs(t, err, ErrUUIDSyntax)
+	_, err = ParseUUID("z0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11")
+	assert.ErrorIs(t, err, ErrUUIDSyntax)
+
+	h := u.HugeInt()
+	assert.Equal(t, int64(0x20eebc999c0b4ef8), h.Upper())
+	assert.Equal(t, u, UUIDFromHugeInt(h))
+
+	var scanned UUID
+	assert.Nil(t, scanned.Scan(u[:]))
+	assert.Equal(t, u, scanned)
+	assert.Error(t, scanned.Scan(42))
+}
+
+func TestUUIDInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+	assert.Nil(t, tester.NoResultQuery("CREATE TABLE entities (id UUID)"))
+
+	ids := []string{"00000000-0000-0000-0000-000000000000", "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", "ffffffff-ffff-ffff-ffff-ffffffffffff"}
+	var stmt PreparedStatement
+	assert.Nil(t, tester.conn.Prepare("INSERT INTO entities VALUES ($1)", &stmt))
+	u0, _ := ParseUUID(ids[0])
+	assert.Nil(t, stmt.BindUUID(1, u0))
+	assert.Nil(t, stmt.ExecutePrepared(nil))
+	stmt.Destroy()
+
+	appender, err := tester.conn.AppenderCreate("", "entities")
+	assert.Nil(t, err)
+	for _, id := range ids[1:] {
+		u, _ := ParseUUID(id)
+		assert.Nil(t, appender.BeginRow())
+		assert.Nil(t, appender.AppendUUID(u))
+		assert.Nil(t, appender.EndRow())
+	}
+	assert.Nil(t, appender.Destroy())
+	assert.Nil(t, tester.NoResultQuery("INSERT INTO entities VALUES (NULL)"))
+
+	var result Result
+	assert.Nil(t, tester.conn.Query("SELECT id FROM entities ORDER BY id NULLS LAST", &result))
+	defer result.Destroy()
+	for row, id := range ids {
+		u, err := result.ValueUUID(0, uint64(row))
+		assert.Nil(t, err)
+		assert.Equal(t, id, u.String())
+	}
+	rows := result.Rows()
+	assert.Equal(t, true, rows.Next())
+	var u UUID
+	assert.Nil(t, rows.Scan(&u))
+	assert.Equal(t, ids[0], u.String())
+
+	chunk, err := result.Chunk(0)
+	assert.Nil(t, err)
+	defer chunk.Destroy()
+	vec, err := chunk.GetVector(0)
+	assert.Nil(t, err)
+	uuids, valid, err := vec.UUIDs(chunk.GetSize())
+	assert.Nil(t, err)
+	assert.Equal(t, []bool{true, true, true, false}, valid)
+	for i, id := range ids {
+		assert.Equal(t, id, uuids[i].String())
+	}
+
+	db, err := sql.Open(DriverName, "")
+	assert.Nil(t, err)
+	defer db.Close()
+	var fromSQL UUID
+	assert.Nil(t, db.QueryRow("SELECT ?::UUID", u).Scan(&fromSQL))
+	assert.Equal(t, u, fromSQL)
+}
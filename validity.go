@@ -4,6 +4,7 @@ package duckdbcapi
 #include <duckdb.h>
 */
 import "C"
+import "unsafe"
 
 type Validity struct {
 	c *C.ulong
@@ -21,3 +22,16 @@ func (v *Validity) SetRowInvalid(row uint64) {
 func (v *Validity) SetRowValid(row uint64) {
 	C.duckdb_validity_set_row_valid(v.c, C.idx_t(row))
 }
+
+// rowIsValid is RowIsValid without the cgo call, for tight decoding loops.
+func (v *Validity) rowIsValid(row uint64) bool {
+	entry := *(*uint64)(unsafe.Add(unsafe.Pointer(v.c), (row/64)*8))
+	return entry&(1<<(row%64)) != 0
+}
+
+// setRowInvalid is SetRowInvalid without the cgo call. The mask must be
+// writable, see Vector.EnsureValidityWritable.
+func (v *Validity) setRowInvalid(row uint64) {
+	entry := (*uint64)(unsafe.Add(unsafe.Pointer(v.c), (row/64)*8))
+	*entry &^= 1 << (row % 64)
+}
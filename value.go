@@ -16,10 +16,13 @@ func CreateVarchar(text string) *Value {
 	return &Value{C.duckdb_create_varchar(cText)}
 }
 
-func CreateVarCharLength(text string, length uint64) *Value {
+func CreateVarCharLength(text string, length uint64) (*Value, error) {
+	if err := checkVarCharLength(text, length); err != nil {
+		return nil, err
+	}
 	cText := C.CString(text)
 	defer C.free(unsafe.Pointer(cText))
-	return &Value{C.duckdb_create_varchar_length(cText, C.idx_t(length))}
+	return &Value{C.duckdb_create_varchar_length(cText, C.idx_t(length))}, nil
 }
 
 func CreateInt64(val int64) *Value {
@@ -27,7 +30,9 @@ func CreateInt64(val int64) *Value {
 }
 
 func (v *Value) GetVarChar() string {
-	return C.GoString(C.duckdb_get_varchar(v.c))
+	cStr := C.duckdb_get_varchar(v.c)
+	defer C.duckdb_free(unsafe.Pointer(cStr))
+	return C.GoString(cStr)
 }
 
 func (v *Value) GetInt64() int64 {
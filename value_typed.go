@@ -0,0 +1,503 @@
+package duckdbcapi
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrValueUnsupported = errors.New("ErrValueUnsupported")
+	ErrValueConversion  = errors.New("ErrValueConversion")
+)
+
+// The v0.3.4 C API can only create VARCHAR and BIGINT values and only read
+// them back as a string or an int64. The CreateText* constructors below
+// therefore create VARCHAR values holding DuckDB's text form of a Go value,
+// which DuckDB casts when the value is used as a parameter of another type.
+// The getters parse the text form of a value, so they work for values of any
+// type that DuckDB can print in that form.
+
+const (
+	dateLayout      = "2006-01-02"
+	timeLayout      = "15:04:05.999999"
+	timestampLayout = "2006-01-02 15:04:05.999999"
+)
+
+func CreateTextBool(v bool) *Value {
+	return CreateVarchar(strconv.FormatBool(v))
+}
+
+func CreateTextUInt64(v uint64) *Value {
+	return CreateVarchar(strconv.FormatUint(v, 10))
+}
+
+func CreateTextFloat(v Float) *Value {
+	return CreateVarchar(strconv.FormatFloat(float64(v), 'g', -1, 32))
+}
+
+func CreateTextDouble(v Double) *Value {
+	return CreateVarchar(strconv.FormatFloat(float64(v), 'g', -1, 64))
+}
+
+func CreateTextDate(v Date) *Value {
+	if v.IsInfinite() {
+		return CreateVarchar(infinityText(v.Days() > 0))
+	}
+	return CreateVarchar(v.Time().Format(dateLayout))
+}
+
+func CreateTextTime(v Time) *Value {
+	return CreateVarchar(time.UnixMicro(v.Micros()).UTC().Format(timeLayout))
+}
+
+func CreateTextTimestamp(v Timestamp) *Value {
+	if v.IsInfinite() {
+		return CreateVarchar(infinityText(v.Micros() > 0))
+	}
+	return CreateVarchar(v.Time().Format(timestampLayout))
+}
+
+func CreateTextUUID(v UUID) *Value {
+	return CreateVarchar(v.String())
+}
+
+func infinityText(positive bool) string {
+	if positive {
+		return "infinity"
+	}
+	return "-infinity"
+}
+
+func CreateTextInterval(v Interval) *Value {
+	return CreateVarchar(fmt.Sprintf("%d months %d days %d microseconds", v.Months(), v.Days(), v.Micros()))
+}
+
+func CreateTextHugeInt(v HugeInt) *Value {
+	return CreateVarchar(v.String())
+}
+
+func CreateTextDecimal(v Decimal) *Value {
+	return CreateVarchar(v.String())
+}
+
+// CreateTextBlob creates a value in the escaped BLOB text form, e.g. `a\x00`.
+func CreateTextBlob(v []byte) *Value {
+	var sb strings.Builder
+	for _, b := range v {
+		if b < 0x20 || b >= 0x7f || b == '\\' {
+			fmt.Fprintf(&sb, "\\x%02X", b)
+		} else {
+			sb.WriteByte(b)
+		}
+	}
+	text := sb.String()
+	value, _ := CreateVarCharLength(text, uint64(len(text)))
+	return value
+}
+
+func (v *Value) conversionError(target string, err error) error {
+	return fmt.Errorf("%w: %q as %s: %v", ErrValueConversion, v.GetVarChar(), target, err)
+}
+
+func (v *Value) GetBool() (bool, error) {
+	b, err := strconv.ParseBool(strings.ToLower(v.GetVarChar()))
+	if err != nil {
+		return false, v.conversionError("BOOLEAN", err)
+	}
+	return b, nil
+}
+
+func (v *Value) parseInt(bits int) (int64, error) {
+	i, err := strconv.ParseInt(v.GetVarChar(), 10, bits)
+	if err != nil {
+		return 0, v.conversionError(fmt.Sprintf("int%d", bits), err)
+	}
+	return i, nil
+}
+
+func (v *Value) parseUint(bits int) (uint64, error) {
+	u, err := strconv.ParseUint(v.GetVarChar(), 10, bits)
+	if err != nil {
+		return 0, v.conversionError(fmt.Sprintf("uint%d", bits), err)
+	}
+	return u, nil
+}
+
+func (v *Value) GetInt8() (int8, error) {
+	i, err := v.parseInt(8)
+	return int8(i), err
+}
+
+func (v *Value) GetInt16() (int16, error) {
+	i, err := v.parseInt(16)
+	return int16(i), err
+}
+
+func (v *Value) GetInt32() (int32, error) {
+	i, err := v.parseInt(32)
+	return int32(i), err
+}
+
+func (v *Value) GetUInt8() (uint8, error) {
+	u, err := v.parseUint(8)
+	return uint8(u), err
+}
+
+func (v *Value) GetUInt16() (uint16, error) {
+	u, err := v.parseUint(16)
+	return uint16(u), err
+}
+
+func (v *Value) GetUInt32() (uint32, error) {
+	u, err := v.parseUint(32)
+	return uint32(u), err
+}
+
+func (v *Value) GetUInt64() (uint64, error) {
+	return v.parseUint(64)
+}
+
+func (v *Value) GetFloat() (Float, error) {
+	f, err := strconv.ParseFloat(v.GetVarChar(), 32)
+	if err != nil {
+		return 0, v.conversionError("FLOAT", err)
+	}
+	return Float(f), nil
+}
+
+func (v *Value) GetDouble() (Double, error) {
+	f, err := strconv.ParseFloat(v.GetVarChar(), 64)
+	if err != nil {
+		return 0, v.conversionError("DOUBLE", err)
+	}
+	return Double(f), nil
+}
+
+func (v *Value) GetDate() (Date, error) {
+	text := v.GetVarChar()
+	switch text {
+	case "infinity":
+		return DateFromTime(InfinityTime), nil
+	case "-infinity":
+		return DateFromTime(NegativeInfinityTime), nil
+	}
+	t, err := time.Parse(dateLayout, text)
+	if err != nil {
+		return Date{}, v.conversionError("DATE", err)
+	}
+	return DateFromTime(t), nil
+}
+
+func (v *Value) GetTime() (Time, error) {
+	t, err := time.Parse(timeLayout, v.GetVarChar())
+	if err != nil {
+		return Time{}, v.conversionError("TIME", err)
+	}
+	return TimeFromTime(t), nil
+}
+
+func (v *Value) GetTimestamp() (Timestamp, error) {
+	text := v.GetVarChar()
+	switch text {
+	case "infinity":
+		return TimestampFromTime(InfinityTime), nil
+	case "-infinity":
+		return TimestampFromTime(NegativeInfinityTime), nil
+	}
+	t, err := time.Parse(timestampLayout, text)
+	if err != nil {
+		return Timestamp{}, v.conversionError("TIMESTAMP", err)
+	}
+	return TimestampFromTime(t), nil
+}
+
+// GetInterval parses DuckDB's interval text form, e.g.
+// "1 year 2 months 3 days 04:05:06.5".
+func (v *Value) GetInterval() (Interval, error) {
+	var months, days int64
+	var micros int64
+	fields := strings.Fields(v.GetVarChar())
+	for i := 0; i < len(fields); i++ {
+		if strings.Contains(fields[i], ":") {
+			d, err := parseClock(fields[i])
+			if err != nil {
+				return Interval{}, v.conversionError("INTERVAL", err)
+			}
+			micros += d
+			continue
+		}
+		n, err := strconv.ParseInt(fields[i], 10, 64)
+		if err != nil || i+1 == len(fields) {
+			return Interval{}, v.conversionError("INTERVAL", fmt.Errorf("unexpected %q", fields[i]))
+		}
+		i++
+		switch strings.TrimSuffix(fields[i], "s") {
+		case "year":
+			months += 12 * n
+		case "month", "mon":
+			months += n
+		case "day":
+			days += n
+		case "hour":
+			micros += n * int64(time.Hour/time.Microsecond)
+		case "minute", "min":
+			micros += n * int64(time.Minute/time.Microsecond)
+		case "second", "sec":
+			micros += n * int64(time.Second/time.Microsecond)
+		case "millisecond", "msec":
+			micros += n * 1000
+		case "microsecond", "usec":
+			micros += n
+		default:
+			return Interval{}, v.conversionError("INTERVAL", fmt.Errorf("unknown unit %q", fields[i]))
+		}
+	}
+	return InitInterval(int32(months), int32(days), micros), nil
+}
+
+// parseClock parses [-]HH:MM:SS[.ffffff] into microseconds.
+func parseClock(s string) (int64, error) {
+	sign := int64(1)
+	if strings.HasPrefix(s, "-") {
+		sign, s = -1, s[1:]
+	}
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	hours, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	minutes, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	seconds, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil {
+		return 0, err
+	}
+	micros := (hours*3600+minutes*60)*1e6 + int64(seconds*1e6+0.5)
+	return sign * micros, nil
+}
+
+func (v *Value) GetHugeInt() (HugeInt, error) {
+	i, ok := new(big.Int).SetString(v.GetVarChar(), 10)
+	if !ok {
+		return HugeInt{}, v.conversionError("HUGEINT", errors.New("not an integer"))
+	}
+	h, ok := bigToHugeInt(i)
+	if !ok {
+		return HugeInt{}, v.conversionError("HUGEINT", errors.New("out of range"))
+	}
+	return h, nil
+}
+
+// GetDecimal parses the value as a decimal whose width and scale are those
+// of its text form, e.g. 12.340 becomes DECIMAL(5,3).
+func (v *Value) GetDecimal() (Decimal, error) {
+	text := v.GetVarChar()
+	digits := strings.TrimPrefix(text, "-")
+	scale := 0
+	if point := strings.IndexByte(digits, '.'); point >= 0 {
+		scale = len(digits) - point - 1
+		digits = digits[:point] + digits[point+1:]
+	}
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return Decimal{}, v.conversionError("DECIMAL", errors.New("not a decimal"))
+	}
+	i, _ := new(big.Int).SetString(digits, 10)
+	width := len(strings.TrimLeft(digits, "0"))
+	if width < scale {
+		width = scale
+	}
+	if width == 0 {
+		width = 1
+	}
+	if width > 38 {
+		return Decimal{}, v.conversionError("DECIMAL", errors.New("more than 38 digits"))
+	}
+	if strings.HasPrefix(text, "-") {
+		i.Neg(i)
+	}
+	h, _ := bigToHugeInt(i)
+	return InitDecimal(uint8(width), uint8(scale), h), nil
+}
+
+// GetBlob decodes the escaped BLOB text form of the value.
+func (v *Value) GetBlob() ([]byte, error) {
+	text := v.GetVarChar()
+	blob := make([]byte, 0, len(text))
+	for i := 0; i < len(text); i++ {
+		if text[i] != '\\' {
+			blob = append(blob, text[i])
+			continue
+		}
+		if i+4 > len(text) || text[i+1] != 'x' {
+			return nil, v.conversionError("BLOB", errors.New("invalid escape"))
+		}
+		b, err := strconv.ParseUint(text[i+2:i+4], 16, 8)
+		if err != nil {
+			return nil, v.conversionError("BLOB", err)
+		}
+		blob = append(blob, byte(b))
+		i += 3
+	}
+	return blob, nil
+}
+
+func (v *Value) GetUUID() (UUID, error) {
+	u, err := ParseUUID(v.GetVarChar())
+	if err != nil {
+		return UUID{}, v.conversionError("UUID", err)
+	}
+	return u, nil
+}
+
+// FromGo creates a Value from a Go bool, integer, float, string, []byte,
+// time.Time (as a TIMESTAMP), *big.Int or one of the Date, Time, Timestamp,
+// Interval, HugeInt, Decimal and UUID types. Integers that fit an int64
+// become BIGINT values, everything else a VARCHAR value in DuckDB's text
+// form, see CreateTextBool and friends.
+func FromGo(v any) (*Value, error) {
+	switch x := v.(type) {
+	case bool:
+		return CreateTextBool(x), nil
+	case int:
+		return CreateInt64(int64(x)), nil
+	case int8:
+		return CreateInt64(int64(x)), nil
+	case int16:
+		return CreateInt64(int64(x)), nil
+	case int32:
+		return CreateInt64(int64(x)), nil
+	case int64:
+		return CreateInt64(x), nil
+	case uint:
+		return CreateTextUInt64(uint64(x)), nil
+	case uint8:
+		return CreateInt64(int64(x)), nil
+	case uint16:
+		return CreateInt64(int64(x)), nil
+	case uint32:
+		return CreateInt64(int64(x)), nil
+	case uint64:
+		return CreateTextUInt64(x), nil
+	case float32:
+		return CreateTextFloat(Float(x)), nil
+	case float64:
+		return CreateTextDouble(Double(x)), nil
+	case Float:
+		return CreateTextFloat(x), nil
+	case Double:
+		return CreateTextDouble(x), nil
+	case string:
+		return CreateVarCharLength(x, uint64(len(x)))
+	case []byte:
+		return CreateTextBlob(x), nil
+	case time.Time:
+		return CreateTextTimestamp(TimestampFromTime(x)), nil
+	case Date:
+		return CreateTextDate(x), nil
+	case Time:
+		return CreateTextTime(x), nil
+	case Timestamp:
+		return CreateTextTimestamp(x), nil
+	case Interval:
+		return CreateTextInterval(x), nil
+	case HugeInt:
+		return CreateTextHugeInt(x), nil
+	case *big.Int:
+		if _, ok := bigToHugeInt(x); !ok {
+			return nil, fmt.Errorf("%w: %s overflows HUGEINT", ErrValueConversion, x)
+		}
+		return CreateVarchar(x.String()), nil
+	case Decimal:
+		return CreateTextDecimal(x), nil
+	case UUID:
+		return CreateTextUUID(x), nil
+	}
+	return nil, fmt.Errorf("%w: cannot create a value from %T", ErrValueUnsupported, v)
+}
+
+// ToGo converts the value to the Go type used by Result and Rows for a
+// column of type lt: time.Time for temporal types, *big.Int for HUGEINT,
+// string for DECIMAL and so on. The type has to be passed in because the
+// v0.3.4 C API cannot report the type of a value. Nested types are not
+// supported.
+func (v *Value) ToGo(lt *LogicalType) (any, error) {
+	switch typ := lt.GetTypeId(); typ {
+	case DuckDBTypeBoolean:
+		return v.GetBool()
+	case DuckDBTypeTinyInt:
+		return v.GetInt8()
+	case DuckDBTypeSmallInt:
+		return v.GetInt16()
+	case DuckDBTypeInteger:
+		return v.GetInt32()
+	case DuckDBTypeBigInt:
+		return v.parseInt(64)
+	case DuckDBTypeUTinyInt:
+		return v.GetUInt8()
+	case DuckDBTypeUSmallInt:
+		return v.GetUInt16()
+	case DuckDBTypeUInteger:
+		return v.GetUInt32()
+	case DuckDBTypeUBigInt:
+		return v.GetUInt64()
+	case DuckDBTypeFloat:
+		f, err := v.GetFloat()
+		if err != nil {
+			return nil, err
+		}
+		return float32(f), nil
+	case DuckDBTypeDouble:
+		f, err := v.GetDouble()
+		if err != nil {
+			return nil, err
+		}
+		return float64(f), nil
+	case DuckDBTypeHugeInt:
+		h, err := v.GetHugeInt()
+		if err != nil {
+			return nil, err
+		}
+		return hugeIntToBig(h), nil
+	case DuckDBTypeDate:
+		d, err := v.GetDate()
+		if err != nil {
+			return nil, err
+		}
+		return d.Time(), nil
+	case DuckDBTypeTime:
+		t, err := v.GetTime()
+		if err != nil {
+			return nil, err
+		}
+		return time.UnixMicro(t.Micros()).UTC(), nil
+	case DuckDBTypeTimestamp, DuckDBTypeTimestamp_S, DuckDBTypeTimestamp_MS, DuckDBTypeTimestamp_NS:
+		ts, err := v.GetTimestamp()
+		if err != nil {
+			return nil, err
+		}
+		return ts.Time(), nil
+	case DuckDBTypeInterval:
+		return v.GetInterval()
+	case DuckDBTypeBlob:
+		return v.GetBlob()
+	case DuckDBTypeDecimal:
+		d, err := v.GetDecimal()
+		if err != nil {
+			return nil, err
+		}
+		return formatDecimal(hugeIntToBig(d.Value()), d.Scale()), nil
+	case DuckDBTypeVarChar, DuckDBTypeJson, DuckDBTypeUUID, DuckDBTypeEnum:
+		return v.GetVarChar(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s values", ErrValueUnsupported, typeName(typ))
+	}
+}
@@ -0,0 +1,148 @@
+package duckdbcapi
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedValuesInCAPI(t *testing.T) {
+	v := CreateTextBool(true)
+	b, err := v.GetBool()
+	assert.Nil(t, err)
+	assert.Equal(t, true, b)
+	v.Destroy()
+
+	v = CreateInt64(-8)
+	i8, err := v.GetInt8()
+	assert.Nil(t, err)
+	assert.Equal(t, int8(-8), i8)
+	_, err = v.GetUInt8()
+	assert.ErrorIs(t, err, ErrValueConversion)
+	v.Destroy()
+
+	v = CreateTextUInt64(1<<64 - 1)
+	u64, err := v.GetUInt64()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1<<64-1), u64)
+	_, err = v.GetInt32()
+	assert.ErrorIs(t, err, ErrValueConversion)
+	v.Destroy()
+
+	v = CreateTextDouble(1.25)
+	d, err := v.GetDouble()
+	assert.Nil(t, err)
+	assert.Equal(t, Double(1.25), d)
+	v.Destroy()
+
+	v = CreateTextDate(InitDate(-1))
+	date, err := v.GetDate()
+	assert.Nil(t, err)
+	assert.Equal(t, "1969-12-31", v.GetVarChar())
+	assert.Equal(t, int32(-1), date.Days())
+	v.Destroy()
+
+	v = CreateTextTime(InitTime(45296000001))
+	tm, err := v.GetTime()
+	assert.Nil(t, err)
+	assert.Equal(t, "12:34:56.000001", v.GetVarChar())
+	assert.Equal(t, int64(45296000001), tm.Micros())
+	v.Destroy()
+
+	v = CreateTextTimestamp(InitTimestamp(715516953123400))
+	ts, err := v.GetTimestamp()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(715516953123400), ts.Micros())
+	v.Destroy()
+
+	v = CreateTextInterval(InitInterval(14, 3, 4000005))
+	interval, err := v.GetInterval()
+	assert.Nil(t, err)
+	assert.Equal(t, int32(14), interval.Months())
+	assert.Equal(t, int32(3), interval.Days())
+	assert.Equal(t, int64(4000005), interval.Micros())
+	v.Destroy()
+
+	_, err = CreateVarCharLength("abc", 4)
+	assert.ErrorIs(t, err, ErrVarCharLength)
+	v, err = CreateVarCharLength("abcdef", 3)
+	assert.Nil(t, err)
+	assert.Equal(t, "abc", v.GetVarChar())
+	v.Destroy()
+
+	v = CreateVarchar("1 year 2 months 3 days 04:05:06.5")
+	interval, err = v.GetInterval()
+	assert.Nil(t, err)
+	assert.Equal(t, int32(14), interval.Months())
+	assert.Equal(t, int32(3), interval.Days())
+	assert.Equal(t, int64(14706500000), interval.Micros())
+	v.Destroy()
+
+	v = CreateTextHugeInt(InitHugInt(1, -1))
+	h, err := v.GetHugeInt()
+	assert.Nil(t, err)
+	assert.Equal(t, "-18446744073709551615", v.GetVarChar())
+	assert.Equal(t, uint64(1), h.Lower())
+	assert.Equal(t, int64(-1), h.Upper())
+	v.Destroy()
+
+	v = CreateTextDecimal(InitDecimal(5, 3, InitHugInt(12340, 0)))
+	dec, err := v.GetDecimal()
+	assert.Nil(t, err)
+	assert.Equal(t, "12.340", v.GetVarChar())
+	assert.Equal(t, uint8(5), dec.Width())
+	assert.Equal(t, uint8(3), dec.Scale())
+	v.Destroy()
+
+	v = CreateTextBlob([]byte{'a', 0, '\\', 0xff})
+	blob, err := v.GetBlob()
+	assert.Nil(t, err)
+	assert.Equal(t, `a\x00\x5C\xFF`, v.GetVarChar())
+	assert.Equal(t, []byte{'a', 0, '\\', 0xff}, blob)
+	v.Destroy()
+
+	_, err = FromGo(struct{}{})
+	assert.ErrorIs(t, err, ErrValueUnsupported)
+	_, err = FromGo(new(big.Int).Lsh(big.NewInt(1), 127))
+	assert.ErrorIs(t, err, ErrValueConversion)
+
+	ts0 := time.Date(1992, 9, 3, 12, 22, 33, 123400000, time.UTC)
+	for _, c := range []struct {
+		in  any
+		typ Type
+		out any
+	}{
+		{true, DuckDBTypeBoolean, true},
+		{int16(7), DuckDBTypeSmallInt, int16(7)},
+		{int64(-7), DuckDBTypeBigInt, int64(-7)},
+		{float32(1.5), DuckDBTypeFloat, float32(1.5)},
+		{"hi", DuckDBTypeVarChar, "hi"},
+		{[]byte{1}, DuckDBTypeBlob, []byte{1}},
+		{ts0, DuckDBTypeTimestamp, ts0},
+		{big.NewInt(-5), DuckDBTypeHugeInt, big.NewInt(-5)},
+	} {
+		v, err := FromGo(c.in)
+		assert.Nil(t, err)
+		lt := CreateLogicalType(c.typ)
+		out, err := v.ToGo(lt)
+		assert.Nil(t, err)
+		assert.Equal(t, c.out, out)
+		lt.Destroy()
+		v.Destroy()
+	}
+
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+	var result Result
+	assert.Nil(t, tester.conn.Query("SELECT [1]", &result))
+	defer result.Destroy()
+	list := result.ColumnLogicalType(0)
+	defer list.Destroy()
+	v = CreateVarchar("[1]")
+	_, err = v.ToGo(list)
+	assert.ErrorIs(t, err, ErrValueUnsupported)
+	v.Destroy()
+}
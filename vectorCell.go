@@ -0,0 +1,602 @@
+package duckdbcapi
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
+	"unsafe"
+)
+
+// cellWriter writes single Go values into the rows of a Vector, converting
+// them to the vector's column type. STRUCT vectors get one writer per field.
+// LIST and MAP vectors only accept NULL and empty values, see
+// ErrVectorListUnsupported. Call destroy to release the logical types.
+type cellWriter struct {
+	vec      *Vector
+	lt       *LogicalType
+	typ      Type
+	data     unsafe.Pointer
+	children []*cellWriter
+	names    []string
+	// nulls is set once a row was marked NULL, so later rows reusing the
+	// chunk are marked valid again
+	nulls bool
+
+	internalType Type
+	width, scale uint8
+	enum         map[string]uint64
+}
+
+func newCellWriter(vec *Vector) (*cellWriter, error) {
+	lt := vec.GetColumnType()
+	w := &cellWriter{vec: vec, lt: lt, typ: lt.GetTypeId()}
+	w.data, _ = vec.GetData()
+	switch w.typ {
+	case DuckDBTypeStruct, DuckDBTypeMap:
+		for i := uint64(0); i < lt.StructTypeChildCount(); i++ {
+			child, err := vec.StructGetChild(i)
+			if err != nil {
+				w.destroy()
+				return nil, err
+			}
+			childWriter, err := newCellWriter(child)
+			if err != nil {
+				w.destroy()
+				return nil, err
+			}
+			w.children = append(w.children, childWriter)
+			w.names = append(w.names, lt.StructTypeChildName(i))
+		}
+	case DuckDBTypeDecimal:
+		w.internalType = lt.DecimalInternalType()
+		w.width, w.scale = lt.DecimalWidth(), lt.DecimalScale()
+	case DuckDBTypeEnum:
+		w.internalType = lt.EnumInternalType()
+	}
+	if w.data == nil && w.typ != DuckDBTypeStruct && w.typ != DuckDBTypeMap {
+		w.destroy()
+		return nil, ErrVectorGetDataNil
+	}
+	return w, nil
+}
+
+func (w *cellWriter) destroy() {
+	for _, child := range w.children {
+		child.destroy()
+	}
+	w.lt.Destroy()
+}
+
+func (w *cellWriter) setNull(row uint64) error {
+	w.vec.EnsureValidityWritable()
+	validity, err := w.vec.GetValidity()
+	if err != nil {
+		return err
+	}
+	validity.SetRowInvalid(row)
+	w.nulls = true
+	for _, child := range w.children {
+		if err := child.setNull(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *cellWriter) setValid(row uint64) {
+	if !w.nulls {
+		return
+	}
+	if validity, err := w.vec.GetValidity(); err == nil {
+		validity.SetRowValid(row)
+	}
+}
+
+func (w *cellWriter) mismatch(v any) error {
+	return fmt.Errorf("%w: cannot write %T to %s", ErrVectorTypeMismatch, v, typeName(w.typ))
+}
+
+// set writes v to row. nil and nil pointers write NULL, other pointers and
+// driver.Valuer implementations write the value they refer to.
+func (w *cellWriter) set(row uint64, v any) error {
+	v, err := indirectValue(v)
+	if err != nil {
+		return err
+	}
+	if v == nil {
+		return w.setNull(row)
+	}
+	w.setValid(row)
+	switch w.typ {
+	case DuckDBTypeBoolean:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Bool {
+			return w.mismatch(v)
+		}
+		*(*bool)(w.cell(row, 1)) = rv.Bool()
+		return nil
+	case DuckDBTypeTinyInt, DuckDBTypeSmallInt, DuckDBTypeInteger, DuckDBTypeBigInt,
+		DuckDBTypeUTinyInt, DuckDBTypeUSmallInt, DuckDBTypeUInteger, DuckDBTypeUBigInt:
+		return w.setInteger(row, v)
+	case DuckDBTypeHugeInt:
+		h, err := w.hugeInt(v)
+		if err != nil {
+			return err
+		}
+		*(*HugeInt)(w.cell(row, unsafe.Sizeof(h))) = h
+		return nil
+	case DuckDBTypeFloat, DuckDBTypeDouble:
+		var f float64
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Float32, reflect.Float64:
+			f = rv.Float()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			f = float64(rv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			f = float64(rv.Uint())
+		default:
+			return w.mismatch(v)
+		}
+		if w.typ == DuckDBTypeFloat {
+			*(*float32)(w.cell(row, 4)) = float32(f)
+		} else {
+			*(*float64)(w.cell(row, 8)) = f
+		}
+		return nil
+	case DuckDBTypeDecimal:
+		return w.setDecimal(row, v)
+	case DuckDBTypeVarChar, DuckDBTypeJson, DuckDBTypeBlob:
+		switch x := v.(type) {
+		case []byte:
+			w.vec.AssignStringElementLen(row, string(x), uint64(len(x)))
+			return nil
+		case string:
+			w.vec.AssignStringElementLen(row, x, uint64(len(x)))
+			return nil
+		}
+		rv := reflect.ValueOf(v)
+		switch {
+		case rv.Kind() == reflect.String:
+			s := rv.String()
+			w.vec.AssignStringElementLen(row, s, uint64(len(s)))
+			return nil
+		case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+			w.vec.AssignStringElementLen(row, string(rv.Bytes()), uint64(rv.Len()))
+			return nil
+		}
+		return w.mismatch(v)
+	case DuckDBTypeUUID:
+		var u UUID
+		switch x := v.(type) {
+		case UUID:
+			u = x
+		case [16]byte:
+			u = x
+		default:
+			rv := reflect.ValueOf(v)
+			if rv.Kind() != reflect.String {
+				return w.mismatch(v)
+			}
+			if u, err = ParseUUID(rv.String()); err != nil {
+				return err
+			}
+		}
+		*(*HugeInt)(w.cell(row, 16)) = u.HugeInt()
+		return nil
+	case DuckDBTypeDate:
+		var d Date
+		switch x := v.(type) {
+		case time.Time:
+			d = DateFromTime(x)
+		case Date:
+			d = x
+		default:
+			return w.mismatch(v)
+		}
+		*(*int32)(w.cell(row, 4)) = d.Days()
+		return nil
+	case DuckDBTypeTime:
+		var t Time
+		switch x := v.(type) {
+		case time.Time:
+			t = TimeFromTime(x)
+		case time.Duration:
+			t = TimeFromDuration(x)
+		case Time:
+			t = x
+		default:
+			return w.mismatch(v)
+		}
+		*(*int64)(w.cell(row, 8)) = t.Micros()
+		return nil
+	case DuckDBTypeTimestamp, DuckDBTypeTimestamp_S, DuckDBTypeTimestamp_MS, DuckDBTypeTimestamp_NS:
+		var micros int64
+		switch x := v.(type) {
+		case time.Time:
+			micros = TimestampPrecisionFromTime(w.typ, x)
+		case Timestamp:
+			if w.typ != DuckDBTypeTimestamp {
+				micros = TimestampPrecisionFromTime(w.typ, x.Time())
+			} else {
+				micros = x.Micros()
+			}
+		default:
+			return w.mismatch(v)
+		}
+		*(*int64)(w.cell(row, 8)) = micros
+		return nil
+	case DuckDBTypeInterval:
+		var i Interval
+		switch x := v.(type) {
+		case time.Duration:
+			i = IntervalFromDuration(x)
+		case Interval:
+			i = x
+		default:
+			return w.mismatch(v)
+		}
+		*(*Interval)(w.cell(row, unsafe.Sizeof(i))) = i
+		return nil
+	case DuckDBTypeEnum:
+		return w.setEnum(row, v)
+	case DuckDBTypeStruct:
+		return w.setStruct(row, v)
+	case DuckDBTypeList, DuckDBTypeMap:
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			if rv.Len() > 0 {
+				return ErrVectorListUnsupported
+			}
+			if w.typ == DuckDBTypeList {
+				*(*listEntry)(w.cell(row, unsafe.Sizeof(listEntry{}))) = listEntry{}
+				return nil
+			}
+			// an empty MAP is a STRUCT of two empty lists
+			return w.setStruct(row, map[string]any{"key": []any{}, "value": []any{}})
+		}
+		return w.mismatch(v)
+	}
+	return fmt.Errorf("%w: %s columns cannot be written", ErrVectorTypeMismatch, typeName(w.typ))
+}
+
+func (w *cellWriter) cell(row uint64, size uintptr) unsafe.Pointer {
+	return unsafe.Add(w.data, uintptr(row)*size)
+}
+
+// indirectValue dereferences pointers and resolves driver.Valuer
+// implementations that are not one of the package's own types.
+func indirectValue(v any) (any, error) {
+	for {
+		switch x := v.(type) {
+		case *big.Int:
+			if x == nil {
+				return nil, nil
+			}
+			return v, nil
+		case nil, UUID, Decimal, HugeInt, time.Time:
+			return v, nil
+		case driver.Valuer:
+			rv := reflect.ValueOf(v)
+			if rv.Kind() == reflect.Ptr && rv.IsNil() {
+				return nil, nil
+			}
+			var err error
+			if v, err = v.(driver.Valuer).Value(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Ptr {
+			return v, nil
+		}
+		if rv.IsNil() {
+			return nil, nil
+		}
+		v = rv.Elem().Interface()
+	}
+}
+
+func (w *cellWriter) setInteger(row uint64, v any) error {
+	var (
+		i      int64
+		u      uint64
+		signed bool
+	)
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, signed = rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u = rv.Uint()
+	default:
+		b, ok := v.(*big.Int)
+		switch {
+		case ok && b.IsInt64():
+			i, signed = b.Int64(), true
+		case ok && b.IsUint64():
+			u = b.Uint64()
+		case ok:
+			return fmt.Errorf("%w: %s overflows %s", ErrVectorOutOfRange, b, typeName(w.typ))
+		default:
+			return w.mismatch(v)
+		}
+	}
+	if signed && i >= 0 {
+		u, signed = uint64(i), false
+	}
+	switch w.typ {
+	case DuckDBTypeTinyInt:
+		return setCellInteger[int8](w, row, i, u, signed)
+	case DuckDBTypeSmallInt:
+		return setCellInteger[int16](w, row, i, u, signed)
+	case DuckDBTypeInteger:
+		return setCellInteger[int32](w, row, i, u, signed)
+	case DuckDBTypeBigInt:
+		return setCellInteger[int64](w, row, i, u, signed)
+	case DuckDBTypeUTinyInt:
+		return setCellInteger[uint8](w, row, i, u, signed)
+	case DuckDBTypeUSmallInt:
+		return setCellInteger[uint16](w, row, i, u, signed)
+	case DuckDBTypeUInteger:
+		return setCellInteger[uint32](w, row, i, u, signed)
+	default:
+		return setCellInteger[uint64](w, row, i, u, signed)
+	}
+}
+
+// setCellInteger stores a negative i (signed) or a non-negative u in a T,
+// failing if it does not fit.
+func setCellInteger[T int8 | int16 | int32 | int64 | uint8 | uint16 | uint32 | uint64](w *cellWriter, row uint64, i int64, u uint64, signed bool) error {
+	var out T
+	if signed {
+		out = T(i)
+		if out >= 0 || int64(out) != i {
+			return fmt.Errorf("%w: value %d overflows %s", ErrVectorOutOfRange, i, typeName(w.typ))
+		}
+	} else {
+		out = T(u)
+		if out < 0 || uint64(out) != u {
+			return fmt.Errorf("%w: value %d overflows %s", ErrVectorOutOfRange, u, typeName(w.typ))
+		}
+	}
+	*(*T)(w.cell(row, unsafe.Sizeof(out))) = out
+	return nil
+}
+
+func (w *cellWriter) hugeInt(v any) (HugeInt, error) {
+	switch x := v.(type) {
+	case HugeInt:
+		return x, nil
+	case *big.Int:
+		return HugeIntFromBig(x)
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return hugeIntFromInt64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return InitHugInt(rv.Uint(), 0), nil
+	}
+	return HugeInt{}, w.mismatch(v)
+}
+
+func (w *cellWriter) setDecimal(row uint64, v any) error {
+	d, err := w.decimal(v)
+	if err != nil {
+		return err
+	}
+	h := d.Value()
+	switch w.internalType {
+	case DuckDBTypeSmallInt:
+		*(*int16)(w.cell(row, 2)) = int16(h.Lower())
+	case DuckDBTypeInteger:
+		*(*int32)(w.cell(row, 4)) = int32(h.Lower())
+	case DuckDBTypeBigInt:
+		*(*int64)(w.cell(row, 8)) = int64(h.Lower())
+	default:
+		*(*HugeInt)(w.cell(row, unsafe.Sizeof(h))) = h
+	}
+	return nil
+}
+
+// decimal converts v to the DECIMAL(width,scale) of the column.
+func (w *cellWriter) decimal(v any) (Decimal, error) {
+	switch x := v.(type) {
+	case Decimal:
+		return x.Rescale(w.width, w.scale)
+	case *big.Int:
+		return DecimalFromRat(new(big.Rat).SetInt(x), w.width, w.scale)
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return ParseDecimal(rv.String(), w.width, w.scale)
+	case reflect.Float32, reflect.Float64:
+		r, ok := new(big.Rat).SetString(strconv.FormatFloat(rv.Float(), 'g', -1, rv.Type().Bits()))
+		if !ok {
+			return Decimal{}, fmt.Errorf("%w: %v", ErrDecimalSyntax, v)
+		}
+		return DecimalFromRat(r, w.width, w.scale)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return DecimalFromRat(new(big.Rat).SetInt64(rv.Int()), w.width, w.scale)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return DecimalFromRat(new(big.Rat).SetUint64(rv.Uint()), w.width, w.scale)
+	}
+	return Decimal{}, w.mismatch(v)
+}
+
+func (w *cellWriter) setEnum(row uint64, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.String {
+		return w.mismatch(v)
+	}
+	if w.enum == nil {
+		size := uint64(w.lt.EnumDictionarySize())
+		w.enum = make(map[string]uint64, size)
+		for i := uint64(0); i < size; i++ {
+			w.enum[w.lt.EnumDictionaryValue(i)] = i
+		}
+	}
+	idx, ok := w.enum[rv.String()]
+	if !ok {
+		return fmt.Errorf("%w: %q is not a value of the ENUM", ErrVectorOutOfRange, rv.String())
+	}
+	switch w.internalType {
+	case DuckDBTypeUTinyInt:
+		*(*uint8)(w.cell(row, 1)) = uint8(idx)
+	case DuckDBTypeUSmallInt:
+		*(*uint16)(w.cell(row, 2)) = uint16(idx)
+	default:
+		*(*uint32)(w.cell(row, 4)) = uint32(idx)
+	}
+	return nil
+}
+
+// setStruct writes a map[string]any or a Go struct, whose fields are matched
+// like in ScanStructs. Missing fields are written as NULL.
+func (w *cellWriter) setStruct(row uint64, v any) error {
+	field, err := structFields(v)
+	if err != nil {
+		return w.mismatch(v)
+	}
+	for i, child := range w.children {
+		value, _ := field(w.names[i])
+		if err := child.set(row, value); err != nil {
+			return fmt.Errorf("field %s: %w", w.names[i], err)
+		}
+	}
+	return nil
+}
+
+// structFields returns a lookup of the fields of a struct or a map with
+// string keys. Struct fields are matched like in ScanStructs.
+func structFields(v any) (func(name string) (any, bool), error) {
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.Kind() == reflect.Struct:
+		plan := structPlanFor(rv.Type())
+		return func(name string) (any, bool) {
+			index := plan.lookup(name)
+			if index == nil {
+				return nil, false
+			}
+			return rv.FieldByIndex(index).Interface(), true
+		}, nil
+	case rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String:
+		return func(name string) (any, bool) {
+			value := rv.MapIndex(reflect.ValueOf(name).Convert(rv.Type().Key()))
+			if !value.IsValid() {
+				return nil, false
+			}
+			return value.Interface(), true
+		}, nil
+	}
+	return nil, ErrVectorTypeMismatch
+}
+
+var (
+	valuerType   = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	bigIntType   = reflect.TypeOf((*big.Int)(nil))
+)
+
+// cellAccepts reports whether values of Go type t can be written to a column
+// of type lt, mirroring cellWriter.set. Interfaces and driver.Valuer
+// implementations are only known at write time and are accepted.
+func cellAccepts(t reflect.Type, lt *LogicalType) bool {
+	if t == bigIntType {
+		switch lt.GetTypeId() {
+		case DuckDBTypeDecimal, DuckDBTypeHugeInt, DuckDBTypeTinyInt, DuckDBTypeSmallInt, DuckDBTypeInteger,
+			DuckDBTypeBigInt, DuckDBTypeUTinyInt, DuckDBTypeUSmallInt, DuckDBTypeUInteger, DuckDBTypeUBigInt:
+			return true
+		}
+		return false
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	kind := t.Kind()
+	switch t {
+	case reflect.TypeOf(UUID{}):
+		return lt.GetTypeId() == DuckDBTypeUUID
+	case reflect.TypeOf(Decimal{}):
+		return lt.GetTypeId() == DuckDBTypeDecimal
+	case reflect.TypeOf(HugeInt{}):
+		return lt.GetTypeId() == DuckDBTypeHugeInt
+	case timeType:
+		switch lt.GetTypeId() {
+		case DuckDBTypeDate, DuckDBTypeTime, DuckDBTypeTimestamp, DuckDBTypeTimestamp_S, DuckDBTypeTimestamp_MS, DuckDBTypeTimestamp_NS:
+			return true
+		}
+		return false
+	case durationType:
+		return lt.GetTypeId() == DuckDBTypeTime || lt.GetTypeId() == DuckDBTypeInterval
+	case reflect.TypeOf(Date{}):
+		return lt.GetTypeId() == DuckDBTypeDate
+	case reflect.TypeOf(Time{}):
+		return lt.GetTypeId() == DuckDBTypeTime
+	case reflect.TypeOf(Timestamp{}):
+		switch lt.GetTypeId() {
+		case DuckDBTypeTimestamp, DuckDBTypeTimestamp_S, DuckDBTypeTimestamp_MS, DuckDBTypeTimestamp_NS:
+			return true
+		}
+		return false
+	case reflect.TypeOf(Interval{}):
+		return lt.GetTypeId() == DuckDBTypeInterval
+	}
+	if kind == reflect.Interface || t.Implements(valuerType) || reflect.PtrTo(t).Implements(valuerType) {
+		return true
+	}
+	isInt := kind >= reflect.Int && kind <= reflect.Uint64
+	isFloat := kind == reflect.Float32 || kind == reflect.Float64
+	switch lt.GetTypeId() {
+	case DuckDBTypeBoolean:
+		return kind == reflect.Bool
+	case DuckDBTypeTinyInt, DuckDBTypeSmallInt, DuckDBTypeInteger, DuckDBTypeBigInt,
+		DuckDBTypeUTinyInt, DuckDBTypeUSmallInt, DuckDBTypeUInteger, DuckDBTypeUBigInt, DuckDBTypeHugeInt:
+		return isInt
+	case DuckDBTypeFloat, DuckDBTypeDouble:
+		return isInt || isFloat
+	case DuckDBTypeDecimal:
+		return isInt || isFloat || kind == reflect.String
+	case DuckDBTypeVarChar, DuckDBTypeJson, DuckDBTypeBlob:
+		return kind == reflect.String || kind == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+	case DuckDBTypeUUID:
+		return kind == reflect.String || t == reflect.TypeOf([16]byte{})
+	case DuckDBTypeEnum:
+		return kind == reflect.String
+	case DuckDBTypeList:
+		return kind == reflect.Slice || kind == reflect.Array
+	case DuckDBTypeMap:
+		return kind == reflect.Map
+	case DuckDBTypeStruct:
+		if kind == reflect.Map {
+			return t.Key().Kind() == reflect.String
+		}
+		if kind != reflect.Struct {
+			return false
+		}
+		plan := structPlanFor(t)
+		for i := uint64(0); i < lt.StructTypeChildCount(); i++ {
+			index := plan.lookup(lt.StructTypeChildName(i))
+			if index == nil {
+				continue
+			}
+			child := lt.StructTypeChildType(i)
+			ok := cellAccepts(t.FieldByIndex(index).Type, child)
+			child.Destroy()
+			if !ok {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
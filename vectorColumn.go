@@ -0,0 +1,199 @@
+package duckdbcapi
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// Column copies the first size rows of vec into a []T. valid[i] is false
+// for NULL rows, whose value is left as the zero T. T must match the
+// physical type of the vector, e.g. int32 for INTEGER, Date for DATE or the
+// internal integer type of DECIMAL and ENUM vectors.
+func Column[T SimpleDataType](vec *Vector, size uint64) ([]T, []bool, error) {
+	if err := checkColumnSize(size); err != nil {
+		return nil, nil, err
+	}
+	lt := vec.GetColumnType()
+	typ := physicalType(lt)
+	lt.Destroy()
+	var zero T
+	if !columnTypeMatches(any(zero), typ) {
+		return nil, nil, fmt.Errorf("%w: cannot read %s vector as %T", ErrVectorTypeMismatch, typeName(typ), zero)
+	}
+	pData, err := vec.GetData()
+	if err != nil {
+		return nil, nil, err
+	}
+	valid := vec.validMask(size)
+	values := make([]T, size)
+	copy(values, UnsafeSimpleDataToSlice[T](pData, size))
+	for i, ok := range valid {
+		if !ok {
+			values[i] = zero
+		}
+	}
+	return values, valid, nil
+}
+
+// Strings decodes the first size rows of a VARCHAR, JSON or BLOB vector.
+func (v *Vector) Strings(size uint64) ([]string, []bool, error) {
+	raw, valid, err := v.UnsafeBytes(size)
+	if err != nil {
+		return nil, nil, err
+	}
+	values := make([]string, size)
+	for i, b := range raw {
+		values[i] = string(b)
+	}
+	return values, valid, nil
+}
+
+// Bytes is like Strings but returns copies of the raw bytes. NULL rows
+// are nil.
+func (v *Vector) Bytes(size uint64) ([][]byte, []bool, error) {
+	raw, valid, err := v.UnsafeBytes(size)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, b := range raw {
+		if b != nil {
+			raw[i] = append([]byte{}, b...)
+		}
+	}
+	return raw, valid, nil
+}
+
+// UnsafeBytes is Bytes without the copy: the returned slices point into
+// the vector and are only valid while the DataChunk that owns it is alive
+// and unchanged. They must not be modified.
+func (v *Vector) UnsafeBytes(size uint64) ([][]byte, []bool, error) {
+	if err := checkColumnSize(size); err != nil {
+		return nil, nil, err
+	}
+	lt := v.GetColumnType()
+	typ := lt.GetTypeId()
+	lt.Destroy()
+	switch typ {
+	case DuckDBTypeVarChar, DuckDBTypeJson, DuckDBTypeBlob:
+	default:
+		return nil, nil, fmt.Errorf("%w: cannot read %s vector as strings", ErrVectorTypeMismatch, typeName(typ))
+	}
+	pData, err := v.GetData()
+	if err != nil {
+		return nil, nil, err
+	}
+	valid := v.validMask(size)
+	values := make([][]byte, size)
+	for i, ok := range valid {
+		if ok {
+			values[i] = unsafeStringBytes(unsafe.Add(pData, uintptr(i)*stringEntrySize))
+		}
+	}
+	return values, valid, nil
+}
+
+// Decimals decodes the first size rows of a DECIMAL vector, whatever its
+// internal storage type.
+func (v *Vector) Decimals(size uint64) ([]Decimal, []bool, error) {
+	if err := checkColumnSize(size); err != nil {
+		return nil, nil, err
+	}
+	lt := v.GetColumnType()
+	defer lt.Destroy()
+	if typ := lt.GetTypeId(); typ != DuckDBTypeDecimal {
+		return nil, nil, fmt.Errorf("%w: cannot read %s vector as decimals", ErrVectorTypeMismatch, typeName(typ))
+	}
+	pData, err := v.GetData()
+	if err != nil {
+		return nil, nil, err
+	}
+	width, scale, internal := lt.DecimalWidth(), lt.DecimalScale(), lt.DecimalInternalType()
+	valid := v.validMask(size)
+	values := make([]Decimal, size)
+	for i, ok := range valid {
+		if !ok {
+			continue
+		}
+		var h HugeInt
+		switch row := uint64(i); internal {
+		case DuckDBTypeSmallInt:
+			h = hugeIntFromInt64(int64(vectorElement[int16](pData, row)))
+		case DuckDBTypeInteger:
+			h = hugeIntFromInt64(int64(vectorElement[int32](pData, row)))
+		case DuckDBTypeBigInt:
+			h = hugeIntFromInt64(vectorElement[int64](pData, row))
+		default:
+			h = vectorElement[HugeInt](pData, row)
+		}
+		values[i] = InitDecimal(width, scale, h)
+	}
+	return values, valid, nil
+}
+
+// checkColumnSize rejects sizes beyond the vector buffer.
+func checkColumnSize(size uint64) error {
+	if size > VectorSize() {
+		return fmt.Errorf("%w: %d rows, vectors hold %d", ErrVectorOutOfRange, size, VectorSize())
+	}
+	return nil
+}
+
+func (v *Vector) validMask(size uint64) []bool {
+	valid := make([]bool, size)
+	validity, err := v.GetValidity()
+	for i := range valid {
+		valid[i] = err != nil || validity.rowIsValid(uint64(i))
+	}
+	return valid
+}
+
+// physicalType returns the type a vector of lt stores its values as.
+func physicalType(lt *LogicalType) Type {
+	switch typ := lt.GetTypeId(); typ {
+	case DuckDBTypeDecimal:
+		return lt.DecimalInternalType()
+	case DuckDBTypeEnum:
+		return lt.EnumInternalType()
+	default:
+		return typ
+	}
+}
+
+func columnTypeMatches(zero any, typ Type) bool {
+	switch zero.(type) {
+	case bool:
+		return typ == DuckDBTypeBoolean
+	case int8:
+		return typ == DuckDBTypeTinyInt
+	case int16:
+		return typ == DuckDBTypeSmallInt
+	case int32:
+		return typ == DuckDBTypeInteger
+	case int64:
+		return typ == DuckDBTypeBigInt
+	case uint8:
+		return typ == DuckDBTypeUTinyInt
+	case uint16:
+		return typ == DuckDBTypeUSmallInt
+	case uint32:
+		return typ == DuckDBTypeUInteger
+	case uint64:
+		return typ == DuckDBTypeUBigInt
+	case Float:
+		return typ == DuckDBTypeFloat
+	case Double:
+		return typ == DuckDBTypeDouble
+	case HugeInt:
+		return typ == DuckDBTypeHugeInt || typ == DuckDBTypeUUID
+	case Date:
+		return typ == DuckDBTypeDate
+	case Time:
+		return typ == DuckDBTypeTime
+	case Timestamp:
+		return typ == DuckDBTypeTimestamp || typ == DuckDBTypeTimestamp_S ||
+			typ == DuckDBTypeTimestamp_MS || typ == DuckDBTypeTimestamp_NS
+	case Interval:
+		return typ == DuckDBTypeInterval
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package duckdbcapi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVectorColumnInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+
+	long := strings.Repeat("x", 40)
+	var result Result
+	assert.Nil(t, tester.conn.Query(`SELECT * FROM (VALUES
+		(1::INTEGER, 'short', 'ab'::BLOB, 1.5::DOUBLE),
+		(NULL, NULL, NULL, NULL),
+		(3, '`+long+`', '\x00\x01'::BLOB, 2.5),
+		(4, '', ''::BLOB, 3.5))`, &result))
+	defer result.Destroy()
+
+	chunk, err := result.Chunk(0)
+	assert.Nil(t, err)
+	defer chunk.Destroy()
+	size := chunk.GetSize()
+	assert.Equal(t, uint64(4), size)
+
+	ints, _ := chunk.GetVector(0)
+	values, valid, err := Column[int32](ints, size)
+	assert.Nil(t, err)
+	assert.Equal(t, []int32{1, 0, 3, 4}, values)
+	assert.Equal(t, []bool{true, false, true, true}, valid)
+	_, _, err = Column[int64](ints, size)
+	assert.ErrorIs(t, err, ErrVectorTypeMismatch)
+
+	doubles, _ := chunk.GetVector(3)
+	floats, _, err := Column[Double](doubles, size)
+	assert.Nil(t, err)
+	assert.Equal(t, []Double{1.5, 0, 2.5, 3.5}, floats)
+
+	strs, _ := chunk.GetVector(1)
+	s, valid, err := strs.Strings(size)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"short", "", long, ""}, s)
+	assert.Equal(t, []bool{true, false, true, true}, valid)
+	_, _, err = ints.Strings(size)
+	assert.ErrorIs(t, err, ErrVectorTypeMismatch)
+
+	blobs, _ := chunk.GetVector(2)
+	b, valid, err := blobs.Bytes(size)
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{[]byte("ab"), nil, {0, 1}, {}}, b)
+	assert.Equal(t, []bool{true, false, true, true}, valid)
+
+	unsafeBytes, _, err := strs.UnsafeBytes(size)
+	assert.Nil(t, err)
+	assert.Equal(t, long, string(unsafeBytes[2]))
+
+	_, _, err = Column[int32](ints, VectorSize()+1)
+	assert.ErrorIs(t, err, ErrVectorOutOfRange)
+	_, _, err = strs.Strings(VectorSize() + 1)
+	assert.ErrorIs(t, err, ErrVectorOutOfRange)
+}
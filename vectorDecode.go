@@ -0,0 +1,245 @@
+package duckdbcapi
+
+import (
+	"math/big"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+// DuckDB stores strings as a 16 byte string_t: a uint32 length followed by
+// either up to 12 inlined bytes or a 4 byte prefix and a pointer.
+const (
+	stringEntrySize    = 16
+	stringInlineLength = 12
+)
+
+type listEntry struct {
+	offset uint64
+	length uint64
+}
+
+// unsafeStringBytes returns the bytes of the string_t at p without copying.
+func unsafeStringBytes(p unsafe.Pointer) []byte {
+	length := *(*uint32)(p)
+	if length == 0 {
+		return []byte{}
+	}
+	if length <= stringInlineLength {
+		return unsafe.Slice((*byte)(unsafe.Add(p, 4)), length)
+	}
+	return unsafe.Slice(*(**byte)(unsafe.Add(p, 8)), length)
+}
+
+func formatDecimal(v *big.Int, scale uint8) string {
+	digits := new(big.Int).Abs(v).String()
+	sign := ""
+	if v.Sign() < 0 {
+		sign = "-"
+	}
+	if scale == 0 {
+		return sign + digits
+	}
+	if len(digits) <= int(scale) {
+		digits = strings.Repeat("0", int(scale)-len(digits)+1) + digits
+	}
+	point := len(digits) - int(scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// vectorReader decodes the rows of a Vector into Go values. Nested vectors
+// get one reader per child. Call destroy to release the logical types.
+type vectorReader struct {
+	lt       *LogicalType
+	typ      Type
+	data     unsafe.Pointer
+	validity *Validity
+	children []*vectorReader
+	names    []string
+
+	internalType Type
+	scale        uint8
+	dictionary   map[uint64]string
+}
+
+func newVectorReader(vec *Vector) (*vectorReader, error) {
+	lt := vec.GetColumnType()
+	r := &vectorReader{lt: lt, typ: lt.GetTypeId()}
+	// STRUCT vectors have no data buffer and fully valid vectors may have no
+	// validity mask
+	r.data, _ = vec.GetData()
+	r.validity, _ = vec.GetValidity()
+	switch r.typ {
+	case DuckDBTypeList:
+		child, err := vec.ListGetChild()
+		if err != nil {
+			r.destroy()
+			return nil, err
+		}
+		childReader, err := newVectorReader(child)
+		if err != nil {
+			r.destroy()
+			return nil, err
+		}
+		r.children = []*vectorReader{childReader}
+	case DuckDBTypeStruct, DuckDBTypeMap:
+		for i := uint64(0); i < lt.StructTypeChildCount(); i++ {
+			child, err := vec.StructGetChild(i)
+			if err != nil {
+				r.destroy()
+				return nil, err
+			}
+			childReader, err := newVectorReader(child)
+			if err != nil {
+				r.destroy()
+				return nil, err
+			}
+			r.children = append(r.children, childReader)
+			r.names = append(r.names, lt.StructTypeChildName(i))
+		}
+	case DuckDBTypeDecimal:
+		r.internalType = lt.DecimalInternalType()
+		r.scale = lt.DecimalScale()
+	case DuckDBTypeEnum:
+		r.internalType = lt.EnumInternalType()
+		r.dictionary = map[uint64]string{}
+	}
+	if r.data == nil && r.typ != DuckDBTypeStruct && r.typ != DuckDBTypeMap {
+		r.destroy()
+		return nil, ErrVectorGetDataNil
+	}
+	return r, nil
+}
+
+func (r *vectorReader) destroy() {
+	for _, child := range r.children {
+		child.destroy()
+	}
+	r.lt.Destroy()
+}
+
+func (r *vectorReader) isValid(row uint64) bool {
+	return r.validity == nil || r.validity.rowIsValid(row)
+}
+
+func vectorElement[T any](data unsafe.Pointer, row uint64) T {
+	var zero T
+	return *(*T)(unsafe.Add(data, uintptr(row)*unsafe.Sizeof(zero)))
+}
+
+func (r *vectorReader) integer(row uint64, typ Type) *big.Int {
+	switch typ {
+	case DuckDBTypeSmallInt:
+		return big.NewInt(int64(vectorElement[int16](r.data, row)))
+	case DuckDBTypeInteger:
+		return big.NewInt(int64(vectorElement[int32](r.data, row)))
+	case DuckDBTypeBigInt:
+		return big.NewInt(vectorElement[int64](r.data, row))
+	default:
+		return hugeIntToBig(vectorElement[HugeInt](r.data, row))
+	}
+}
+
+func (r *vectorReader) enumIndex(row uint64) uint64 {
+	switch r.internalType {
+	case DuckDBTypeUTinyInt:
+		return uint64(vectorElement[uint8](r.data, row))
+	case DuckDBTypeUSmallInt:
+		return uint64(vectorElement[uint16](r.data, row))
+	default:
+		return uint64(vectorElement[uint32](r.data, row))
+	}
+}
+
+// value returns row as a Go value: primitives map to their Go counterparts,
+// VARCHAR/ENUM/JSON/UUID/DECIMAL to string, BLOB to []byte, temporal types
+// to time.Time, HUGEINT to *big.Int, LIST to []any, STRUCT to
+// map[string]any and MAP to map[any]any. NULL is returned as nil.
+func (r *vectorReader) value(row uint64) any {
+	if !r.isValid(row) {
+		return nil
+	}
+	switch r.typ {
+	case DuckDBTypeBoolean:
+		return vectorElement[bool](r.data, row)
+	case DuckDBTypeTinyInt:
+		return vectorElement[int8](r.data, row)
+	case DuckDBTypeSmallInt:
+		return vectorElement[int16](r.data, row)
+	case DuckDBTypeInteger:
+		return vectorElement[int32](r.data, row)
+	case DuckDBTypeBigInt:
+		return vectorElement[int64](r.data, row)
+	case DuckDBTypeUTinyInt:
+		return vectorElement[uint8](r.data, row)
+	case DuckDBTypeUSmallInt:
+		return vectorElement[uint16](r.data, row)
+	case DuckDBTypeUInteger:
+		return vectorElement[uint32](r.data, row)
+	case DuckDBTypeUBigInt:
+		return vectorElement[uint64](r.data, row)
+	case DuckDBTypeFloat:
+		return vectorElement[float32](r.data, row)
+	case DuckDBTypeDouble:
+		return vectorElement[float64](r.data, row)
+	case DuckDBTypeHugeInt:
+		return hugeIntToBig(vectorElement[HugeInt](r.data, row))
+	case DuckDBTypeDecimal:
+		return formatDecimal(r.integer(row, r.internalType), r.scale)
+	case DuckDBTypeUUID:
+		return UUIDFromHugeInt(vectorElement[HugeInt](r.data, row)).String()
+	case DuckDBTypeDate:
+		d := vectorElement[Date](r.data, row)
+		return d.Time()
+	case DuckDBTypeTime:
+		return time.UnixMicro(vectorElement[int64](r.data, row)).UTC()
+	case DuckDBTypeTimestamp, DuckDBTypeTimestamp_S, DuckDBTypeTimestamp_MS, DuckDBTypeTimestamp_NS:
+		return TimestampPrecisionTime(r.typ, vectorElement[int64](r.data, row))
+	case DuckDBTypeInterval:
+		return vectorElement[Interval](r.data, row)
+	case DuckDBTypeVarChar, DuckDBTypeJson:
+		return string(unsafeStringBytes(unsafe.Add(r.data, uintptr(row)*stringEntrySize)))
+	case DuckDBTypeBlob:
+		return append([]byte{}, unsafeStringBytes(unsafe.Add(r.data, uintptr(row)*stringEntrySize))...)
+	case DuckDBTypeEnum:
+		idx := r.enumIndex(row)
+		s, ok := r.dictionary[idx]
+		if !ok {
+			s = r.lt.EnumDictionaryValue(idx)
+			r.dictionary[idx] = s
+		}
+		return s
+	case DuckDBTypeList:
+		entry := vectorElement[listEntry](r.data, row)
+		list := make([]any, entry.length)
+		for i := range list {
+			list[i] = r.children[0].value(entry.offset + uint64(i))
+		}
+		return list
+	case DuckDBTypeStruct:
+		fields := make(map[string]any, len(r.children))
+		for i, child := range r.children {
+			fields[r.names[i]] = child.value(row)
+		}
+		return fields
+	case DuckDBTypeMap:
+		keys, _ := r.children[0].value(row).([]any)
+		values, _ := r.children[1].value(row).([]any)
+		m := make(map[any]any, len(keys))
+		for i, key := range keys {
+			if i < len(values) && isComparable(key) {
+				m[key] = values[i]
+			}
+		}
+		return m
+	}
+	return nil
+}
+
+func isComparable(v any) bool {
+	switch v.(type) {
+	case []any, []byte, map[string]any, map[any]any:
+		return false
+	}
+	return true
+}
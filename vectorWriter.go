@@ -0,0 +1,207 @@
+package duckdbcapi
+
+import (
+	"fmt"
+	"time"
+	"unsafe"
+)
+
+// VectorWriter fills a Vector of a DataChunk from Go slices, e.g. the output
+// chunk of a table function or a chunk passed to Appender.AppendDataChunk.
+// Every setter checks the Go type against the vector's column type and the
+// row count against VectorSize, and writes rows starting at 0. The chunk
+// size still has to be set with DataChunk.SetSize.
+type VectorWriter struct {
+	vec *Vector
+	typ Type
+}
+
+func NewVectorWriter(vec *Vector) *VectorWriter {
+	lt := vec.GetColumnType()
+	defer lt.Destroy()
+	return &VectorWriter{vec: vec, typ: lt.GetTypeId()}
+}
+
+func (w *VectorWriter) Type() Type {
+	return w.typ
+}
+
+func (w *VectorWriter) data(goType string, rows int, types ...Type) (unsafe.Pointer, error) {
+	matched := false
+	for _, t := range types {
+		matched = matched || t == w.typ
+	}
+	if !matched {
+		return nil, fmt.Errorf("%w: cannot write %s to %s vector", ErrVectorTypeMismatch, goType, typeName(w.typ))
+	}
+	if uint64(rows) > VectorSize() {
+		return nil, fmt.Errorf("%w: %d rows exceed the vector size %d", ErrVectorOutOfRange, rows, VectorSize())
+	}
+	return w.vec.GetData()
+}
+
+func (w *VectorWriter) SetBools(values []bool) error {
+	p, err := w.data("bool", len(values), DuckDBTypeBoolean)
+	if err != nil {
+		return err
+	}
+	copy(unsafe.Slice((*bool)(p), len(values)), values)
+	return nil
+}
+
+// SetInt64s writes values to any integer vector, failing if a value does
+// not fit the column type.
+func (w *VectorWriter) SetInt64s(values []int64) error {
+	p, err := w.data("int64", len(values), DuckDBTypeTinyInt, DuckDBTypeSmallInt, DuckDBTypeInteger,
+		DuckDBTypeBigInt, DuckDBTypeUTinyInt, DuckDBTypeUSmallInt, DuckDBTypeUInteger, DuckDBTypeUBigInt)
+	if err != nil {
+		return err
+	}
+	switch w.typ {
+	case DuckDBTypeTinyInt:
+		return setIntegers[int8](p, values)
+	case DuckDBTypeSmallInt:
+		return setIntegers[int16](p, values)
+	case DuckDBTypeInteger:
+		return setIntegers[int32](p, values)
+	case DuckDBTypeBigInt:
+		return setIntegers[int64](p, values)
+	case DuckDBTypeUTinyInt:
+		return setIntegers[uint8](p, values)
+	case DuckDBTypeUSmallInt:
+		return setIntegers[uint16](p, values)
+	case DuckDBTypeUInteger:
+		return setIntegers[uint32](p, values)
+	default:
+		return setIntegers[uint64](p, values)
+	}
+}
+
+func setIntegers[T int8 | int16 | int32 | int64 | uint8 | uint16 | uint32 | uint64](p unsafe.Pointer, values []int64) error {
+	out := unsafe.Slice((*T)(p), len(values))
+	var zero T
+	unsigned := zero-1 > zero
+	for i, v := range values {
+		if int64(T(v)) != v || (unsigned && v < 0) {
+			return fmt.Errorf("%w: value %d at row %d overflows %T", ErrVectorOutOfRange, v, i, zero)
+		}
+		out[i] = T(v)
+	}
+	return nil
+}
+
+func (w *VectorWriter) SetFloat64s(values []float64) error {
+	p, err := w.data("float64", len(values), DuckDBTypeFloat, DuckDBTypeDouble)
+	if err != nil {
+		return err
+	}
+	if w.typ == DuckDBTypeFloat {
+		out := unsafe.Slice((*float32)(p), len(values))
+		for i, v := range values {
+			out[i] = float32(v)
+		}
+		return nil
+	}
+	copy(unsafe.Slice((*float64)(p), len(values)), values)
+	return nil
+}
+
+func (w *VectorWriter) SetStrings(values []string) error {
+	if _, err := w.data("string", len(values), DuckDBTypeVarChar, DuckDBTypeJson); err != nil {
+		return err
+	}
+	for i, v := range values {
+		w.vec.AssignStringElementLen(uint64(i), v, uint64(len(v)))
+	}
+	return nil
+}
+
+func (w *VectorWriter) SetBlobs(values [][]byte) error {
+	if _, err := w.data("[]byte", len(values), DuckDBTypeBlob); err != nil {
+		return err
+	}
+	for i, v := range values {
+		w.vec.AssignStringElementLen(uint64(i), string(v), uint64(len(v)))
+	}
+	return nil
+}
+
+// SetTimes writes values to a DATE, TIME or TIMESTAMP vector. DATE and TIME
+// use the calendar date and wall clock of each value in its own location.
+func (w *VectorWriter) SetTimes(values []time.Time) error {
+	p, err := w.data("time.Time", len(values), DuckDBTypeDate, DuckDBTypeTime, DuckDBTypeTimestamp,
+		DuckDBTypeTimestamp_S, DuckDBTypeTimestamp_MS, DuckDBTypeTimestamp_NS)
+	if err != nil {
+		return err
+	}
+	if w.typ == DuckDBTypeDate {
+		out := unsafe.Slice((*int32)(p), len(values))
+		for i, v := range values {
+			d := DateFromTime(v)
+			out[i] = d.Days()
+		}
+		return nil
+	}
+	out := unsafe.Slice((*int64)(p), len(values))
+	for i, v := range values {
+		if w.typ == DuckDBTypeTime {
+			t := TimeFromTime(v)
+			out[i] = t.Micros()
+		} else {
+			out[i] = TimestampPrecisionFromTime(w.typ, v)
+		}
+	}
+	return nil
+}
+
+// SetNull marks row as NULL. For STRUCT vectors the row is also marked NULL
+// in every child.
+func (w *VectorWriter) SetNull(row uint64) error {
+	if row >= VectorSize() {
+		return fmt.Errorf("%w: row %d exceeds the vector size %d", ErrVectorOutOfRange, row, VectorSize())
+	}
+	w.vec.EnsureValidityWritable()
+	validity, err := w.vec.GetValidity()
+	if err != nil {
+		return err
+	}
+	validity.SetRowInvalid(row)
+	if w.typ != DuckDBTypeStruct {
+		return nil
+	}
+	lt := w.vec.GetColumnType()
+	defer lt.Destroy()
+	for i := uint64(0); i < lt.StructTypeChildCount(); i++ {
+		child, err := w.Child(i)
+		if err != nil {
+			return err
+		}
+		if err := child.SetNull(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Child returns a writer for field index of a STRUCT vector. LIST vectors
+// cannot be written: the v0.3.4 C API has no way to resize the list child
+// vector, so ErrVectorListUnsupported is returned for them.
+func (w *VectorWriter) Child(index uint64) (*VectorWriter, error) {
+	switch w.typ {
+	case DuckDBTypeStruct:
+		lt := w.vec.GetColumnType()
+		count := lt.StructTypeChildCount()
+		lt.Destroy()
+		if index >= count {
+			return nil, fmt.Errorf("%w: field %d of %d", ErrVectorOutOfRange, index, count)
+		}
+		child, err := w.vec.StructGetChild(index)
+		if err != nil {
+			return nil, err
+		}
+		return NewVectorWriter(child), nil
+	case DuckDBTypeList:
+		return nil, ErrVectorListUnsupported
+	}
+	return nil, fmt.Errorf("%w: %s vector has no children", ErrVectorTypeMismatch, typeName(w.typ))
+}
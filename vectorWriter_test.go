@@ -0,0 +1,108 @@
+package duckdbcapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVectorWriterInCAPI(t *testing.T) {
+	var tester CAPITester
+	assert.Equal(t, true, tester.OpenDatabase(""))
+	defer tester.CleanUp()
+
+	assert.Nil(t, tester.NoResultQuery(`CREATE TABLE out (i SMALLINT, f FLOAT, s VARCHAR, b BLOB,
+		d DATE, ts TIMESTAMP, st STRUCT(a INTEGER, b VARCHAR), l INTEGER[])`))
+
+	var schema Result
+	assert.Nil(t, tester.conn.Query("SELECT * FROM out LIMIT 0", &schema))
+	types := make([]*LogicalType, schema.ColumnCount())
+	for i := range types {
+		types[i] = schema.ColumnLogicalType(uint64(i))
+		defer types[i].Destroy()
+	}
+	schema.Destroy()
+
+	chunk, err := CreateDataChunk(types, uint64(len(types)))
+	assert.Nil(t, err)
+	defer chunk.Destroy()
+	writer := func(col uint64) *VectorWriter {
+		vec, err := chunk.GetVector(col)
+		assert.Nil(t, err)
+		return NewVectorWriter(vec)
+	}
+
+	i := writer(0)
+	assert.Equal(t, DuckDBTypeSmallInt, i.Type())
+	assert.ErrorIs(t, i.SetInt64s([]int64{1 << 20}), ErrVectorOutOfRange)
+	assert.ErrorIs(t, i.SetStrings([]string{"x"}), ErrVectorTypeMismatch)
+	assert.Nil(t, i.SetInt64s([]int64{1, -2}))
+	assert.Nil(t, writer(1).SetFloat64s([]float64{1.5, 2.5}))
+	assert.Nil(t, writer(2).SetStrings([]string{"short", "a string longer than twelve bytes"}))
+	assert.Nil(t, writer(3).SetBlobs([][]byte{{0, 1}, {}}))
+	day := time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC)
+	ts := time.Date(2022, 5, 1, 10, 30, 0, 1000, time.UTC)
+	assert.Nil(t, writer(4).SetTimes([]time.Time{day, day}))
+	assert.Nil(t, writer(5).SetTimes([]time.Time{ts, ts}))
+
+	st := writer(6)
+	a, err := st.Child(0)
+	assert.Nil(t, err)
+	assert.Nil(t, a.SetInt64s([]int64{7, 0}))
+	b, err := st.Child(1)
+	assert.Nil(t, err)
+	assert.Nil(t, b.SetStrings([]string{"x", ""}))
+	_, err = st.Child(2)
+	assert.ErrorIs(t, err, ErrVectorOutOfRange)
+	assert.Nil(t, st.SetNull(1))
+
+	l := writer(7)
+	_, err = l.Child(0)
+	assert.ErrorIs(t, err, ErrVectorListUnsupported)
+	assert.Nil(t, l.SetNull(0))
+	assert.Nil(t, l.SetNull(1))
+	assert.ErrorIs(t, l.SetNull(VectorSize()), ErrVectorOutOfRange)
+
+	chunk.SetSize(2)
+	appender, err := tester.conn.AppenderCreate("", "out")
+	assert.Nil(t, err)
+	assert.Nil(t, appender.AppendDataChunk(chunk))
+	assert.Nil(t, appender.Destroy())
+
+	var result Result
+	assert.Nil(t, tester.conn.Query("SELECT i, f, s, b, d, ts, st.a, st.b, st IS NULL, l FROM out", &result))
+	defer result.Destroy()
+	rows := result.Rows()
+	assert.Equal(t, true, rows.Next())
+	var (
+		gotI        int16
+		gotF        float32
+		gotS        string
+		gotB        []byte
+		gotD, gotTS time.Time
+		gotA        *int32
+		gotStB      *string
+		stNull      bool
+		gotL        any
+	)
+	assert.Nil(t, rows.Scan(&gotI, &gotF, &gotS, &gotB, &gotD, &gotTS, &gotA, &gotStB, &stNull, &gotL))
+	assert.Equal(t, int16(1), gotI)
+	assert.Equal(t, float32(1.5), gotF)
+	assert.Equal(t, "short", gotS)
+	assert.Equal(t, []byte{0, 1}, gotB)
+	assert.Equal(t, day, gotD)
+	assert.Equal(t, ts, gotTS)
+	assert.Equal(t, int32(7), *gotA)
+	assert.Equal(t, "x", *gotStB)
+	assert.Equal(t, false, stNull)
+	assert.Nil(t, gotL)
+
+	assert.Equal(t, true, rows.Next())
+	assert.Nil(t, rows.Scan(&gotI, &gotF, &gotS, &gotB, &gotD, &gotTS, &gotA, &gotStB, &stNull, &gotL))
+	assert.Equal(t, int16(-2), gotI)
+	assert.Equal(t, "a string longer than twelve bytes", gotS)
+	assert.Equal(t, []byte{}, gotB)
+	assert.Nil(t, gotA)
+	assert.Equal(t, true, stNull)
+}